@@ -0,0 +1,564 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/logic"
+)
+
+func (v *v1) initBeneficiaryTypes(meetTypes meetingTypes) beneficiaryTypes {
+	optionalTime := func(getter func(impact.Beneficiary) *time.Time) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			obj, ok := p.Source.(impact.Beneficiary)
+			if !ok {
+				return nil, errors.New("Expecting an impact.Beneficiary")
+			}
+			t := getter(obj)
+			if t == nil {
+				return nil, nil
+			}
+			return t.Format(time.RFC3339), nil
+		}
+	}
+
+	profileValue := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ProfileValue",
+		Description: "A beneficiary's value for a profile field. Only the value field matching the profile field's type is set",
+		Fields: graphql.Fields{
+			"fieldID": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the profile field",
+			},
+			"stringValue": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The value of an enum or text field",
+			},
+			"numberValue": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The value of a number field",
+			},
+			"dateValue": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The value of a date field as an ISO standard timestamp",
+			},
+		},
+	})
+
+	profileValueInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "ProfileValueInput",
+		Description: "A value for a profile field. Exactly one of the value fields must be provided, matching the profile field's type",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"fieldID": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the profile field",
+			},
+			"stringValue": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "The value of an enum or text field",
+			},
+			"numberValue": &graphql.InputObjectFieldConfig{
+				Type:        graphql.Float,
+				Description: "The value of a number field",
+			},
+			"dateValue": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "The value of a date field. Should be ISO standard timestamp",
+			},
+		},
+	})
+
+	changeTypeEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "BeneficiaryChangeType",
+		Description: "How a beneficiary's ID was changed",
+		Values: graphql.EnumValueConfigMap{
+			string(impact.RENAME): &graphql.EnumValueConfig{
+				Value:       impact.RENAME,
+				Description: "The beneficiary's meetings were moved to a new beneficiary ID",
+			},
+			string(impact.MERGE): &graphql.EnumValueConfig{
+				Value:       impact.MERGE,
+				Description: "The beneficiary's meetings were moved to an existing beneficiary",
+			},
+		},
+	})
+
+	beneficiaryChange := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "BeneficiaryChange",
+		Description: "An audit record of a beneficiary being renamed or merged into another beneficiary",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Unique ID for the change",
+			},
+			"type": &graphql.Field{
+				Type:        graphql.NewNonNull(changeTypeEnum),
+				Description: "Whether the beneficiary was renamed or merged",
+			},
+			"from": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The beneficiary ID the meetings were moved from",
+			},
+			"to": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The beneficiary ID the meetings were moved to",
+			},
+			"meetingIDs": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "The IDs of the meetings which were moved",
+			},
+			"user": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The user who made the change",
+			},
+			"created": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "When the change was made",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.BeneficiaryChange)
+					if !ok {
+						return nil, errors.New("Expecting an impact.BeneficiaryChange")
+					}
+					return obj.Created.Format(time.RFC3339), nil
+				},
+			},
+			"reversed": &graphql.Field{
+				Type:        graphql.String,
+				Description: "When the change was reversed. Null if the change has not been reversed",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.BeneficiaryChange)
+					if !ok {
+						return nil, errors.New("Expecting an impact.BeneficiaryChange")
+					}
+					if obj.Reversed == nil {
+						return nil, nil
+					}
+					return obj.Reversed.Format(time.RFC3339), nil
+				},
+			},
+			"reversedBy": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The user who reversed the change",
+			},
+		},
+	})
+
+	erasure := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Erasure",
+		Description: "A tombstone recording that a beneficiary's data was erased. The beneficiary's ID is not retained",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Unique ID for the erasure",
+			},
+			"beneficiaryHash": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "A hash of the erased beneficiary's ID, keyed with a server secret. Use the beneficiaryID argument of erasures to check whether a beneficiary has been erased",
+			},
+			"meetingsErased": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of meetings deleted",
+			},
+			"changesErased": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of beneficiary renames and merges deleted",
+			},
+			"user": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The user who requested the erasure",
+			},
+			"created": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "When the erasure was requested",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Erasure)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Erasure")
+					}
+					return obj.Created.Format(time.RFC3339), nil
+				},
+			},
+			"completed": &graphql.Field{
+				Type:        graphql.String,
+				Description: "When all of the beneficiary's data had been removed. Null if the erasure did not complete",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Erasure)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Erasure")
+					}
+					if obj.Completed == nil {
+						return nil, nil
+					}
+					return obj.Completed.Format(time.RFC3339), nil
+				},
+			},
+		},
+	})
+
+	return beneficiaryTypes{
+		erasure:           erasure,
+		beneficiaryChange: beneficiaryChange,
+		profileValueInput: profileValueInput,
+		beneficiaryType: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "Beneficiary",
+			Description: "A person whose journey of change is tracked through meetings",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The organisation's identifier for the beneficiary",
+				},
+				"created": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "When the beneficiary's first meeting was entered into the system",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						obj, ok := p.Source.(impact.Beneficiary)
+						if !ok {
+							return nil, errors.New("Expecting an impact.Beneficiary")
+						}
+						return obj.Created.Format(time.RFC3339), nil
+					},
+				},
+				"firstMeeting": &graphql.Field{
+					Type:        graphql.String,
+					Description: "When the beneficiary's first meeting was conducted. Null if all of the beneficiary's meetings have been deleted",
+					Resolve: optionalTime(func(b impact.Beneficiary) *time.Time {
+						return b.FirstMeeting
+					}),
+				},
+				"lastMeeting": &graphql.Field{
+					Type:        graphql.String,
+					Description: "When the beneficiary's most recent meeting was conducted. Null if all of the beneficiary's meetings have been deleted",
+					Resolve: optionalTime(func(b impact.Beneficiary) *time.Time {
+						return b.LastMeeting
+					}),
+				},
+				"meetingCount": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "The number of meetings the beneficiary has had",
+				},
+				"archived": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Boolean),
+					Description: "Archived beneficiaries are hidden from the beneficiaries list by default",
+				},
+				"tags": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+					Description: "The tags the organisation has given the beneficiary, such as the programmes they attend",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						obj, ok := p.Source.(impact.Beneficiary)
+						if !ok {
+							return nil, errors.New("Expecting an impact.Beneficiary")
+						}
+						if obj.Tags == nil {
+							return []string{}, nil
+						}
+						return obj.Tags, nil
+					},
+				},
+				"profile": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(profileValue)),
+					Description: "The beneficiary's values for the organisation's profile fields",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						obj, ok := p.Source.(impact.Beneficiary)
+						if !ok {
+							return nil, errors.New("Expecting an impact.Beneficiary")
+						}
+						return toProfileValues(obj.Profile), nil
+					},
+				},
+				"meetings": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(meetTypes.meetingType)),
+					Description: "The beneficiary's meetings",
+					Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+						obj, ok := p.Source.(impact.Beneficiary)
+						if !ok {
+							return nil, errors.New("Expecting an impact.Beneficiary")
+						}
+						return v.db.GetMeetingsForBeneficiary(obj.ID, u)
+					}),
+				},
+			},
+		}),
+	}
+}
+
+func (v *v1) getBeneficiaryQueries(benTypes beneficiaryTypes) graphql.Fields {
+	return graphql.Fields{
+		"beneficiary": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Get a beneficiary by ID",
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{
+					Description: "The ID of the beneficiary",
+					Type:        graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.GetBeneficiary(p.Args["id"].(string), u)
+			}),
+		},
+		"beneficiaryTags": &graphql.Field{
+			Type:        graphql.NewList(graphql.String),
+			Description: "Get every tag used by the organisation's beneficiaries",
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.GetBeneficiaryTags(u)
+			}),
+		},
+		"beneficiaryChanges": &graphql.Field{
+			Type:        graphql.NewList(benTypes.beneficiaryChange),
+			Description: "Get the organisation's beneficiary renames and merges, newest first",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Description: "Only return changes to or from this beneficiary",
+					Type:        graphql.String,
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.GetBeneficiaryChanges(getNullableString(p.Args, "beneficiaryID"), u)
+			}),
+		},
+		"subjectAccessExport": &graphql.Field{
+			Type:        graphql.String,
+			Description: "Gathers all of the data held about a beneficiary, including deleted meetings, into a JSON document. Restricted to organisation admins",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Description: "The ID of the beneficiary",
+					Type:        graphql.NewNonNull(graphql.String),
+				},
+			},
+			Resolve: adminRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				export, err := logic.GetSubjectAccessExport(p.Args["beneficiaryID"].(string), v.db, u)
+				if err != nil {
+					return nil, err
+				}
+				b, err := json.MarshalIndent(export, "", "  ")
+				if err != nil {
+					return nil, err
+				}
+				return string(b), nil
+			}),
+		},
+		"erasures": &graphql.Field{
+			Type:        graphql.NewList(benTypes.erasure),
+			Description: "Get the organisation's erasure tombstones, newest first. Restricted to organisation admins",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Description: "Only return erasures of this beneficiary",
+					Type:        graphql.String,
+				},
+			},
+			Resolve: adminRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.GetErasures(getNullableString(p.Args, "beneficiaryID"), u)
+			}),
+		},
+		"beneficiaries": &graphql.Field{
+			Type:        graphql.NewList(benTypes.beneficiaryType),
+			Description: "Get all of the organisation's beneficiaries",
+			Args: graphql.FieldConfigArgument{
+				"includeArchived": &graphql.ArgumentConfig{
+					Description:  "Whether archived beneficiaries should be returned",
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.GetBeneficiaries(p.Args["includeArchived"].(bool), u)
+			}),
+		},
+	}
+}
+
+func (v *v1) getBeneficiaryMutations(benTypes beneficiaryTypes) graphql.Fields {
+	setArchived := func(archived bool) graphql.FieldResolveFn {
+		return userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+			return v.db.SetBeneficiaryArchived(p.Args["beneficiaryID"].(string), archived, u)
+		})
+	}
+	args := graphql.FieldConfigArgument{
+		"beneficiaryID": &graphql.ArgumentConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "The ID of the beneficiary",
+		},
+	}
+	tagArgs := graphql.FieldConfigArgument{
+		"beneficiaryID": &graphql.ArgumentConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "The ID of the beneficiary",
+		},
+		"tags": &graphql.ArgumentConfig{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			Description: "The tags",
+		},
+	}
+	return graphql.Fields{
+		"AddBeneficiaryTags": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Adds tags to a beneficiary. Tags the beneficiary already has are ignored",
+			Args:        tagArgs,
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				tags, err := getStrings(p.Args, "tags")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.AddBeneficiaryTags(p.Args["beneficiaryID"].(string), tags, u)
+			}),
+		},
+		"RemoveBeneficiaryTags": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Removes tags from a beneficiary",
+			Args:        tagArgs,
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				tags, err := getStrings(p.Args, "tags")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.RemoveBeneficiaryTags(p.Args["beneficiaryID"].(string), tags, u)
+			}),
+		},
+		"SetBeneficiaryProfile": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Stores values for profile fields against a beneficiary, replacing any existing values for those fields",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the beneficiary",
+				},
+				"values": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(benTypes.profileValueInput))),
+					Description: "The values to store",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				values, err := getProfileValues(p.Args, "values")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.SetBeneficiaryProfileValues(p.Args["beneficiaryID"].(string), values, u)
+			}),
+		},
+		"ClearBeneficiaryProfileValue": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Removes a beneficiary's value for a profile field",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the beneficiary",
+				},
+				"fieldID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the profile field",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.ClearBeneficiaryProfileValue(p.Args["beneficiaryID"].(string), p.Args["fieldID"].(string), u)
+			}),
+		},
+		"RenameBeneficiary": &graphql.Field{
+			Type:        benTypes.beneficiaryChange,
+			Description: "Moves all of a beneficiary's meetings to a new beneficiary ID, for example to correct a mistyped ID. The change can be reversed",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The current ID of the beneficiary",
+				},
+				"newID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The new ID of the beneficiary, must not already be in use",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.RenameBeneficiary(p.Args["beneficiaryID"].(string), p.Args["newID"].(string), u)
+			}),
+		},
+		"MergeBeneficiaries": &graphql.Field{
+			Type:        benTypes.beneficiaryChange,
+			Description: "Moves all of the source beneficiary's meetings to the target beneficiary, combining their journeys. The target's tags and profile are kept. The change can be reversed",
+			Args: graphql.FieldConfigArgument{
+				"sourceID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the beneficiary to merge into the target",
+				},
+				"targetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the beneficiary which will receive the source's meetings",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.MergeBeneficiaries(p.Args["sourceID"].(string), p.Args["targetID"].(string), u)
+			}),
+		},
+		"ReverseBeneficiaryChange": &graphql.Field{
+			Type:        benTypes.beneficiaryChange,
+			Description: "Reverses a rename or merge, returning the moved meetings to their original beneficiary",
+			Args: graphql.FieldConfigArgument{
+				"changeID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the change",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.ReverseBeneficiaryChange(p.Args["changeID"].(string), u)
+			}),
+		},
+		"EraseBeneficiary": &graphql.Field{
+			Type:        benTypes.erasure,
+			Description: "Permanently deletes all data held about a beneficiary and records a tombstone. This cannot be undone. Restricted to organisation admins",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the beneficiary",
+				},
+			},
+			Resolve: adminRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.EraseBeneficiary(p.Args["beneficiaryID"].(string), u)
+			}),
+		},
+		"ArchiveBeneficiary": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Archives a beneficiary, hiding them from the beneficiaries list. Their meetings are unaffected",
+			Args:        args,
+			Resolve:     setArchived(true),
+		},
+		"UnarchiveBeneficiary": &graphql.Field{
+			Type:        benTypes.beneficiaryType,
+			Description: "Returns an archived beneficiary to the beneficiaries list",
+			Args:        args,
+			Resolve:     setArchived(false),
+		},
+	}
+}
+
+type profileValue struct {
+	FieldID     string   `json:"fieldID"`
+	StringValue *string  `json:"stringValue"`
+	NumberValue *float64 `json:"numberValue"`
+	DateValue   *string  `json:"dateValue"`
+}
+
+// toProfileValues converts a beneficiary's profile into a list ordered by field ID
+func toProfileValues(profile map[string]interface{}) []profileValue {
+	out := make([]profileValue, 0, len(profile))
+	for fieldID, value := range profile {
+		pv := profileValue{FieldID: fieldID}
+		switch v := value.(type) {
+		case string:
+			pv.StringValue = &v
+		case float64:
+			pv.NumberValue = &v
+		case time.Time:
+			s := v.Format(time.RFC3339)
+			pv.DateValue = &s
+		default:
+			continue
+		}
+		out = append(out, pv)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].FieldID < out[j].FieldID
+	})
+	return out
+}
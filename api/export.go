@@ -0,0 +1,303 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+	"github.com/impactasaurus/server/export"
+	"github.com/impactasaurus/server/log"
+	"github.com/impactasaurus/server/logic"
+)
+
+type exporter struct {
+	db data.Base
+}
+
+type userAuthenticatedHandler func(w http.ResponseWriter, r *http.Request, u auth.User) error
+
+// NewExport returns a handler serving file exports of the organisation's data.
+// It expects to be mounted at /v1/export/ behind auth.Middleware.
+func NewExport(db data.Base) http.Handler {
+	e := &exporter{
+		db: db,
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/v1/export/joc.csv", e.userRestrictedHandler(e.jocCSV))
+	mux.Handle("/v1/export/meetings.csv", e.userRestrictedHandler(e.meetingsCSV))
+	mux.Handle("/v1/export/joc.xlsx", e.userRestrictedHandler(e.jocXLSX))
+	mux.Handle("/v1/export/joc.pdf", e.userRestrictedHandler(e.jocPDF))
+	return mux
+}
+
+func (e *exporter) userRestrictedHandler(fn userAuthenticatedHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Only GET requests are supported", http.StatusMethodNotAllowed)
+			return
+		}
+		u, err := auth.GetUser(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err := fn(w, r, u); err != nil {
+			writeExportError(w, r, u, err)
+		}
+	})
+}
+
+// streamError is returned when writing the export fails after the response has started
+type streamError struct {
+	error
+}
+
+func writeExportError(w http.ResponseWriter, r *http.Request, u auth.User, err error) {
+	if _, ok := err.(impact.ValidationErrors); ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if data.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log.Error(err, map[string]string{
+		"path": r.URL.Path,
+		"uid":  u.UserID(),
+	})
+	if _, ok := err.(streamError); !ok {
+		http.Error(w, "Export failed", http.StatusInternalServerError)
+	}
+}
+
+// writeFile sets the headers required for the response to be downloaded as a file then writes the file
+func writeFile(w http.ResponseWriter, contentType, filename string, write func(io.Writer) error) error {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if err := write(w); err != nil {
+		return streamError{err}
+	}
+	return nil
+}
+
+func getRequiredQuery(q url.Values, key string) (string, error) {
+	v := q.Get(key)
+	if v == "" {
+		return "", impact.ValidationErrors{{Field: key, Message: fmt.Sprintf("%s is required", key)}}
+	}
+	return v, nil
+}
+
+func getQueryTime(q url.Values, key string) (time.Time, error) {
+	v, err := getRequiredQuery(q, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, impact.ValidationErrors{{Field: key, Message: "Should be ISO standard timestamp"}}
+	}
+	return t, nil
+}
+
+func getQueryBool(q url.Values, key string) (bool, error) {
+	v := q.Get(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, impact.ValidationErrors{{Field: key, Message: "Should be true or false"}}
+	}
+	return b, nil
+}
+
+// reportQuery holds the query parameters shared by the exports
+type reportQuery struct {
+	start         time.Time
+	end           time.Time
+	questionSetID string
+	completedOnly bool
+}
+
+func getReportQuery(q url.Values) (reportQuery, error) {
+	var ret reportQuery
+	var err error
+	if ret.start, err = getQueryTime(q, "start"); err != nil {
+		return ret, err
+	}
+	if ret.end, err = getQueryTime(q, "end"); err != nil {
+		return ret, err
+	}
+	if ret.questionSetID, err = getRequiredQuery(q, "questionSetID"); err != nil {
+		return ret, err
+	}
+	if ret.completedOnly, err = getQueryBool(q, "completedOnly"); err != nil {
+		return ret, err
+	}
+	return ret, nil
+}
+
+// getJOCReport produces the JOC report described by the query parameters.
+// Supports the start, end, questionSetID, completedOnly, includeTags, excludeTags, baseline and baselineDate parameters,
+// which match the arguments of the JOCServiceReport query.
+func (e *exporter) getJOCReport(q url.Values, rq reportQuery, u auth.User) (*impact.JOCServiceReport, impact.OutcomeSet, error) {
+	opts := logic.JOCOptions{
+		CompletedOnly: rq.completedOnly,
+		IncludeTags:   q["includeTags"],
+		ExcludeTags:   q["excludeTags"],
+		Baseline:      impact.BaselineStrategy(q.Get("baseline")),
+	}
+	if q.Get("baselineDate") != "" {
+		baselineDate, err := getQueryTime(q, "baselineDate")
+		if err != nil {
+			return nil, impact.OutcomeSet{}, err
+		}
+		opts.BaselineDate = &baselineDate
+	}
+	os, err := e.db.GetOutcomeSet(rq.questionSetID, u)
+	if err != nil {
+		return nil, impact.OutcomeSet{}, err
+	}
+	report, err := logic.GetJOCServiceReport(rq.start, rq.end, rq.questionSetID, opts, e.db, u)
+	if err != nil {
+		return nil, impact.OutcomeSet{}, err
+	}
+	return report, os, nil
+}
+
+func (e *exporter) jocCSV(w http.ResponseWriter, r *http.Request, u auth.User) error {
+	q := r.URL.Query()
+	rq, err := getReportQuery(q)
+	if err != nil {
+		return err
+	}
+	report, os, err := e.getJOCReport(q, rq, u)
+	if err != nil {
+		return err
+	}
+	return writeFile(w, "text/csv; charset=utf-8", "joc.csv", func(out io.Writer) error {
+		return export.JOCCSV(out, report, os)
+	})
+}
+
+// getMeetings returns the question set's meetings conducted between start and end
+func (e *exporter) getMeetings(rq reportQuery, u auth.User) ([]impact.Meeting, error) {
+	meetings, err := e.db.GetOSMeetingsInTimeRange(rq.start, rq.end, rq.questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	if !rq.completedOnly {
+		return meetings, nil
+	}
+	completed := make([]impact.Meeting, 0, len(meetings))
+	for _, m := range meetings {
+		if m.IsComplete() {
+			completed = append(completed, m)
+		}
+	}
+	return completed, nil
+}
+
+// meetingsCSV exports the answers of the question set's meetings conducted between start and end
+func (e *exporter) meetingsCSV(w http.ResponseWriter, r *http.Request, u auth.User) error {
+	rq, err := getReportQuery(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	os, err := e.db.GetOutcomeSet(rq.questionSetID, u)
+	if err != nil {
+		return err
+	}
+	meetings, err := e.getMeetings(rq, u)
+	if err != nil {
+		return err
+	}
+	return writeFile(w, "text/csv; charset=utf-8", "meetings.csv", func(out io.Writer) error {
+		return export.MeetingsCSV(out, meetings, os)
+	})
+}
+
+// getJOCData gathers the JOC report, along with the organisation and raw meetings, for file exports
+func (e *exporter) getJOCData(r *http.Request, u auth.User) (export.JOCData, error) {
+	q := r.URL.Query()
+	rq, err := getReportQuery(q)
+	if err != nil {
+		return export.JOCData{}, err
+	}
+	report, os, err := e.getJOCReport(q, rq, u)
+	if err != nil {
+		return export.JOCData{}, err
+	}
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return export.JOCData{}, err
+	}
+	org, err := e.db.GetOrganisation(userOrg, u)
+	if err != nil {
+		return export.JOCData{}, err
+	}
+	meetings, err := e.getMeetings(rq, u)
+	if err != nil {
+		return export.JOCData{}, err
+	}
+	return export.JOCData{
+		Organisation: org,
+		OutcomeSet:   os,
+		Start:        rq.start,
+		End:          rq.end,
+		Report:       report,
+		Meetings:     meetings,
+	}, nil
+}
+
+// exportFilename names an export after the question set and the date range, such as wellbeing-2017-01-01-2017-06-30.xlsx.
+// Only ASCII letters and digits from the question set's name are kept, so the name is safe to use in headers.
+func exportFilename(os impact.OutcomeSet, start, end time.Time, extension string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r > unicode.MaxASCII:
+			return -1
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			return '-'
+		default:
+			return -1
+		}
+	}, os.Name)
+	if name == "" {
+		name = "report"
+	}
+	return fmt.Sprintf("%s-%s-%s.%s", name, start.Format("2006-01-02"), end.Format("2006-01-02"), extension)
+}
+
+func (e *exporter) jocXLSX(w http.ResponseWriter, r *http.Request, u auth.User) error {
+	d, err := e.getJOCData(r, u)
+	if err != nil {
+		return err
+	}
+	filename := exportFilename(d.OutcomeSet, d.Start, d.End, "xlsx")
+	return writeFile(w, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", filename, func(out io.Writer) error {
+		return export.JOCXLSX(out, d)
+	})
+}
+
+func (e *exporter) jocPDF(w http.ResponseWriter, r *http.Request, u auth.User) error {
+	d, err := e.getJOCData(r, u)
+	if err != nil {
+		return err
+	}
+	filename := exportFilename(d.OutcomeSet, d.Start, d.End, "pdf")
+	return writeFile(w, "application/pdf", filename, func(out io.Writer) error {
+		return export.JOCPDF(out, d)
+	})
+}
@@ -1,5 +1,13 @@
 package api
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+)
+
 func getNullableString(input map[string]interface{}, key string) string {
 	s := ""
 	r := input[key]
@@ -25,3 +33,209 @@ func getNullableInt(input map[string]interface{}, key string) int {
 	}
 	return s
 }
+
+func getNullOrFloat(input map[string]interface{}, key string) (float64, bool) {
+	r := input[key]
+	if r != nil {
+		return r.(float64), true
+	}
+	return 0, false
+}
+
+func getStrings(input map[string]interface{}, key string) ([]string, error) {
+	r, ok := input[key].([]interface{})
+	if !ok {
+		return nil, errors.New("Expected a list of strings")
+	}
+	out := make([]string, 0, len(r))
+	for _, i := range r {
+		s, ok := i.(string)
+		if !ok {
+			return nil, errors.New("Expected a string")
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func getChoices(input map[string]interface{}, key string) ([]impact.Choice, error) {
+	r, ok := input[key].([]interface{})
+	if !ok {
+		return nil, errors.New("Expected a list of choices")
+	}
+	choices := make([]impact.Choice, 0, len(r))
+	for _, c := range r {
+		cMap, ok := c.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("Expected a choice")
+		}
+		choice := impact.Choice{
+			ID:    getNullableString(cMap, "id"),
+			Label: getNullableString(cMap, "label"),
+		}
+		if score, ok := cMap["score"].(float64); ok {
+			s := float32(score)
+			choice.Score = &s
+		}
+		choices = append(choices, choice)
+	}
+	return choices, nil
+}
+
+// getOptionalStrings returns the list of strings argument or nil if the argument was not provided
+func getOptionalStrings(input map[string]interface{}, key string) ([]string, error) {
+	if input[key] == nil {
+		return nil, nil
+	}
+	return getStrings(input, key)
+}
+
+// getOptionalFloats returns the list of floats argument or nil if the argument was not provided
+func getOptionalFloats(input map[string]interface{}, key string) ([]float64, error) {
+	if input[key] == nil {
+		return nil, nil
+	}
+	r, ok := input[key].([]interface{})
+	if !ok {
+		return nil, errors.New("Expected a list of numbers")
+	}
+	out := make([]float64, 0, len(r))
+	for _, i := range r {
+		f, ok := i.(float64)
+		if !ok {
+			return nil, errors.New("Expected a list of numbers")
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// getNullableTime parses an optional ISO timestamp argument, nil is returned if the argument was not provided
+func getNullableTime(input map[string]interface{}, key string) (*time.Time, error) {
+	r, ok := input[key].(string)
+	if !ok {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, r)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// getAnswer converts an AnswerInput argument into an impact.Answer
+func getAnswer(input map[string]interface{}, key string) (impact.Answer, error) {
+	a, ok := input[key].(map[string]interface{})
+	if !ok {
+		return impact.Answer{}, errors.New("Expected an answer")
+	}
+	return toAnswer(a)
+}
+
+// getAnswers converts a list of AnswerInput arguments into impact.Answers
+func getAnswers(input map[string]interface{}, key string) ([]impact.Answer, error) {
+	raw, ok := input[key].([]interface{})
+	if !ok {
+		return nil, errors.New("Expected a list of answers")
+	}
+	out := make([]impact.Answer, 0, len(raw))
+	for idx, r := range raw {
+		a, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("Expected an answer")
+		}
+		answer, err := toAnswer(a)
+		if err != nil {
+			if verr, ok := err.(impact.ValidationErrors); ok {
+				for i := range verr {
+					verr[i].Field = fmt.Sprintf("answers[%d].%s", idx, verr[i].Field)
+				}
+			}
+			return nil, err
+		}
+		out = append(out, answer)
+	}
+	return out, nil
+}
+
+func toAnswer(a map[string]interface{}) (impact.Answer, error) {
+	answer := impact.Answer{
+		QuestionID: getNullableString(a, "questionID"),
+	}
+	provided := 0
+	if r, ok := a["intValue"]; ok && r != nil {
+		answer.Type = impact.INT
+		answer.Answer = r.(int)
+		provided++
+	}
+	if r, ok := a["stringValue"]; ok && r != nil {
+		answer.Type = impact.STRING
+		answer.Answer = r.(string)
+		provided++
+	}
+	if r, ok := a["floatValue"]; ok && r != nil {
+		answer.Type = impact.FLOAT
+		answer.Answer = r.(float64)
+		provided++
+	}
+	if r, ok := a["choiceID"]; ok && r != nil {
+		answer.Type = impact.CHOICE
+		answer.Answer = r.(string)
+		provided++
+	}
+	if r, ok := a["choiceIDs"]; ok && r != nil {
+		choiceIDs, err := getStrings(a, "choiceIDs")
+		if err != nil {
+			return impact.Answer{}, err
+		}
+		answer.Type = impact.CHOICES
+		answer.Answer = choiceIDs
+		provided++
+	}
+	if provided != 1 {
+		return impact.Answer{}, impact.ValidationErrors{{
+			Field:   "answer",
+			Message: "Exactly one value must be provided",
+		}}
+	}
+	return answer, nil
+}
+
+// getProfileValues converts a list of ProfileValueInput arguments into values keyed by profile field ID
+func getProfileValues(input map[string]interface{}, key string) (map[string]interface{}, error) {
+	raw, ok := input[key].([]interface{})
+	if !ok {
+		return nil, errors.New("Expected a list of profile values")
+	}
+	out := make(map[string]interface{}, len(raw))
+	for idx, r := range raw {
+		pv, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("Expected a profile value")
+		}
+		field := fmt.Sprintf("values[%d].value", idx)
+		var value interface{}
+		provided := 0
+		if s, ok := pv["stringValue"].(string); ok {
+			value = s
+			provided++
+		}
+		if f, ok := pv["numberValue"].(float64); ok {
+			value = f
+			provided++
+		}
+		if d, ok := pv["dateValue"].(string); ok {
+			t, err := time.Parse(time.RFC3339, d)
+			if err != nil {
+				return nil, impact.ValidationErrors{{Field: field, Message: "Dates should be ISO standard timestamps"}}
+			}
+			value = t
+			provided++
+		}
+		if provided != 1 {
+			return nil, impact.ValidationErrors{{Field: field, Message: "Exactly one value must be provided"}}
+		}
+		out[getNullableString(pv, "fieldID")] = value
+	}
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/impactasaurus/server/api"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// postGraphQL sends the query to the v1 API as the user, returning the decoded response
+func postGraphQL(t *testing.T, db *mock.MockBase, u *mock.MockUser, query string) graphQLResponse {
+	h, err := api.NewV1(db)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/v1/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(auth.NewContext(req.Context(), u))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var out graphQLResponse
+	if !assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &out)) {
+		t.FailNow()
+	}
+	return out
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"github.com/graphql-go/graphql"
 	impact "github.com/impactasaurus/server"
 	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
 	"github.com/impactasaurus/server/logic"
 	"time"
 )
@@ -29,6 +31,14 @@ func (v *v1) initMeetingTypes(orgTypes organisationTypes, osTypes outcomeSetType
 			switch obj.Type {
 			case impact.INT:
 				return ret.intAnswer
+			case impact.STRING:
+				return ret.stringAnswer
+			case impact.CHOICE:
+				return ret.choiceAnswer
+			case impact.CHOICES:
+				return ret.choicesAnswer
+			case impact.FLOAT:
+				return ret.floatAnswer
 			default:
 				return ret.intAnswer
 			}
@@ -64,6 +74,145 @@ func (v *v1) initMeetingTypes(orgTypes organisationTypes, osTypes outcomeSetType
 		},
 	})
 
+	ret.stringAnswer = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StringAnswer",
+		Description: "Answer containing a string value",
+		Interfaces: []*graphql.Interface{
+			ret.answerInterface,
+		},
+		Fields: graphql.Fields{
+			"questionID": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the question answered",
+			},
+			"answer": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The provided string answer",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Answer)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Answer")
+					}
+					str, ok := obj.Answer.(string)
+					if !ok {
+						return nil, errors.New("Expected a string value")
+					}
+					return str, nil
+				},
+			},
+		},
+	})
+
+	ret.choiceAnswer = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ChoiceAnswer",
+		Description: "Answer containing the ID of the selected choice",
+		Interfaces: []*graphql.Interface{
+			ret.answerInterface,
+		},
+		Fields: graphql.Fields{
+			"questionID": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the question answered",
+			},
+			"answer": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the selected choice",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Answer)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Answer")
+					}
+					str, ok := obj.Answer.(string)
+					if !ok {
+						return nil, errors.New("Expected a string value")
+					}
+					return str, nil
+				},
+			},
+		},
+	})
+
+	ret.choicesAnswer = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MultipleChoiceAnswer",
+		Description: "Answer containing the IDs of the selected choices",
+		Interfaces: []*graphql.Interface{
+			ret.answerInterface,
+		},
+		Fields: graphql.Fields{
+			"questionID": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the question answered",
+			},
+			"answer": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "The IDs of the selected choices",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Answer)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Answer")
+					}
+					return obj.ChoiceIDs()
+				},
+			},
+		},
+	})
+
+	ret.floatAnswer = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FloatAnswer",
+		Description: "Answer containing a decimal value",
+		Interfaces: []*graphql.Interface{
+			ret.answerInterface,
+		},
+		Fields: graphql.Fields{
+			"questionID": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the question answered",
+			},
+			"answer": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The provided decimal answer",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Answer)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Answer")
+					}
+					return obj.ToFloat64()
+				},
+			},
+		},
+	})
+
+	ret.answerInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "AnswerInput",
+		Description: "An answer to a question. Exactly one of the value fields should be provided, matching the type of the question being answered",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"questionID": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the question being answered",
+			},
+			"intValue": &graphql.InputObjectFieldConfig{
+				Type:        graphql.Int,
+				Description: "The value given for a likert scale question",
+			},
+			"stringValue": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "The text given for a free text question",
+			},
+			"floatValue": &graphql.InputObjectFieldConfig{
+				Type:        graphql.Float,
+				Description: "The value given for a numeric question",
+			},
+			"choiceID": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "The ID of the choice selected for a single choice question",
+			},
+			"choiceIDs": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+				Description: "The IDs of the choices selected for a multiple choice question",
+			},
+		},
+	})
+
 	ret.categoryAggregate = graphql.NewObject(graphql.ObjectConfig{
 		Name:        "CategoryAggregate",
 		Description: "An aggregation of answers to the category level",
@@ -90,6 +239,25 @@ func (v *v1) initMeetingTypes(orgTypes organisationTypes, osTypes outcomeSetType
 		},
 	})
 
+	ret.meetingStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "MeetingStatus",
+		Description: "The stage of a meeting's lifecycle",
+		Values: graphql.EnumValueConfigMap{
+			string(impact.INPROGRESS): &graphql.EnumValueConfig{
+				Value:       impact.INPROGRESS,
+				Description: "Answers are still being recorded",
+			},
+			string(impact.COMPLETE): &graphql.EnumValueConfig{
+				Value:       impact.COMPLETE,
+				Description: "All questions have been answered",
+			},
+			string(impact.ABANDONED): &graphql.EnumValueConfig{
+				Value:       impact.ABANDONED,
+				Description: "The meeting was stopped before it was completed",
+			},
+		},
+	})
+
 	ret.meetingType = graphql.NewObject(graphql.ObjectConfig{
 		Name:        "Meeting",
 		Description: "A set of answers for an outcome set",
@@ -136,6 +304,21 @@ func (v *v1) initMeetingTypes(orgTypes organisationTypes, osTypes outcomeSetType
 					return v.db.GetOrganisation(obj.OrganisationID, u)
 				}),
 			},
+			"status": &graphql.Field{
+				Type:        graphql.NewNonNull(ret.meetingStatusEnum),
+				Description: "Whether the meeting is in progress, complete or abandoned",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Meeting)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Meeting")
+					}
+					return obj.GetStatus(), nil
+				},
+			},
+			"baseline": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether the meeting is marked as the beneficiary's baseline for the outcome set",
+			},
 			"answers": &graphql.Field{
 				Type:        graphql.NewNonNull(graphql.NewList(ret.answerInterface)),
 				Description: "The answers provided in the meeting",
@@ -196,11 +379,160 @@ func (v *v1) initMeetingTypes(orgTypes organisationTypes, osTypes outcomeSetType
 			},
 		},
 	})
+
+	ret.meetingSortEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "MeetingSort",
+		Description: "The fields meetings can be ordered by",
+		Values: graphql.EnumValueConfigMap{
+			string(data.SortByConducted): &graphql.EnumValueConfig{
+				Value:       data.SortByConducted,
+				Description: "When the meeting was conducted",
+			},
+			string(data.SortByCreated): &graphql.EnumValueConfig{
+				Value:       data.SortByCreated,
+				Description: "When the meeting was entered into the system",
+			},
+		},
+	})
+
+	meetingEdge := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MeetingEdge",
+		Description: "A meeting within a page of meetings",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Provide as the after argument to fetch the meetings following this meeting",
+			},
+			"node": &graphql.Field{
+				Type:        graphql.NewNonNull(ret.meetingType),
+				Description: "The meeting",
+			},
+		},
+	})
+
+	pageInfo := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MeetingPageInfo",
+		Description: "Details of how to fetch further pages of meetings",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether there are more meetings after this page",
+			},
+			"endCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The cursor of the last meeting in the page. Null if the page is empty",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(data.MeetingPage)
+					if !ok {
+						return nil, errors.New("Expecting a data.MeetingPage")
+					}
+					if c := obj.EndCursor(); c != "" {
+						return c, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	ret.meetingConnection = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MeetingConnection",
+		Description: "A page of meetings",
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(meetingEdge)),
+				Description: "The meetings within the page",
+			},
+			"pageInfo": &graphql.Field{
+				Type:        graphql.NewNonNull(pageInfo),
+				Description: "Details of how to fetch the next page",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+
 	return ret
 }
 
 func (v *v1) getMeetingQueries(meetTypes meetingTypes) graphql.Fields {
 	return graphql.Fields{
+		"meetingsConnection": &graphql.Field{
+			Type:        meetTypes.meetingConnection,
+			Description: "Page through the organisation's meetings, optionally filtering them. Meetings are returned newest first unless ascending is set",
+			Args: graphql.FieldConfigArgument{
+				"first": &graphql.ArgumentConfig{
+					Type:         graphql.Int,
+					DefaultValue: 20,
+					Description:  fmt.Sprintf("The number of meetings to return, at most %d", data.MaxMeetingPageSize),
+				},
+				"after": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Return the meetings following the meeting with this cursor",
+				},
+				"sortBy": &graphql.ArgumentConfig{
+					Type:         meetTypes.meetingSortEnum,
+					DefaultValue: data.SortByConducted,
+					Description:  "The field to order the meetings by",
+				},
+				"ascending": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Return the oldest meetings first",
+				},
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Only return meetings using this outcome set",
+				},
+				"start": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Only return meetings conducted at or after this time. Should be ISO standard timestamp",
+				},
+				"end": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Only return meetings conducted at or before this time. Should be ISO standard timestamp",
+				},
+				"user": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Only return meetings recorded by this user",
+				},
+				"beneficiaryPrefix": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Only return meetings with beneficiaries whose ID starts with this prefix",
+				},
+				"includeDeleted": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Whether deleted meetings should be returned",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				start, err := getNullableTime(p.Args, "start")
+				if err != nil {
+					return nil, err
+				}
+				end, err := getNullableTime(p.Args, "end")
+				if err != nil {
+					return nil, err
+				}
+				filter := data.MeetingFilter{
+					OutcomeSetID:      getNullableString(p.Args, "outcomeSetID"),
+					User:              getNullableString(p.Args, "user"),
+					BeneficiaryPrefix: getNullableString(p.Args, "beneficiaryPrefix"),
+					ConductedAfter:    start,
+					ConductedBefore:   end,
+					IncludeDeleted:    p.Args["includeDeleted"].(bool),
+				}
+				page := data.MeetingPageRequest{
+					First:     p.Args["first"].(int),
+					After:     getNullableString(p.Args, "after"),
+					SortBy:    p.Args["sortBy"].(data.MeetingSortField),
+					Ascending: p.Args["ascending"].(bool),
+				}
+				return v.db.GetMeetings(filter, page, u)
+			}),
+		},
 		"meeting": &graphql.Field{
 			Type:        meetTypes.meetingType,
 			Description: "Get a meeting by meeting ID",
@@ -260,6 +592,42 @@ func (v *v1) getMeetingMutations(meetTypes meetingTypes) graphql.Fields {
 				return v.db.NewMeeting(beneficiaryID, outcomeSetID, parsedConducted, u)
 			}),
 		},
+		"AddMeetingWithAnswers": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Create a new meeting along with all of its answers. The answers are validated together and either the meeting and every answer is stored or nothing is",
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID associated with the beneficiary being interviewed",
+				},
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the outcome set being used",
+				},
+				"conducted": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The time and date when the meeting was conducted. Should be ISO standard timestamp",
+				},
+				"answers": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(meetTypes.answerInput))),
+					Description: "The answers given during the meeting, each question may only be answered once",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				beneficiaryID := p.Args["beneficiaryID"].(string)
+				outcomeSetID := p.Args["outcomeSetID"].(string)
+				conducted := p.Args["conducted"].(string)
+				parsedConducted, err := time.Parse(time.RFC3339, conducted)
+				if err != nil {
+					return nil, err
+				}
+				answers, err := getAnswers(p.Args, "answers")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.NewMeetingWithAnswers(beneficiaryID, outcomeSetID, parsedConducted, answers, u)
+			}),
+		},
 		"AddLikertAnswer": &graphql.Field{
 			Type:        meetTypes.meetingType,
 			Description: "Provide an answer for a Likert Scale question",
@@ -288,6 +656,235 @@ func (v *v1) getMeetingMutations(meetTypes meetingTypes) graphql.Fields {
 				}, u)
 			}),
 		},
-		//"DeleteMeeting",
+		"AddFreeTextAnswer": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Provide an answer for a free text question",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the meeting the answer is associated with",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question being answered",
+				},
+				"value": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The text provided in answer to the question",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				meetingID := p.Args["meetingID"].(string)
+				questionID := p.Args["questionID"].(string)
+				value := p.Args["value"].(string)
+				return v.db.NewAnswer(meetingID, impact.Answer{
+					QuestionID: questionID,
+					Type:       impact.STRING,
+					Answer:     value,
+				}, u)
+			}),
+		},
+		"AddSingleChoiceAnswer": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Provide an answer for a single choice question",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the meeting the answer is associated with",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question being answered",
+				},
+				"choiceID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the selected choice",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				meetingID := p.Args["meetingID"].(string)
+				questionID := p.Args["questionID"].(string)
+				choiceID := p.Args["choiceID"].(string)
+				return v.db.NewAnswer(meetingID, impact.Answer{
+					QuestionID: questionID,
+					Type:       impact.CHOICE,
+					Answer:     choiceID,
+				}, u)
+			}),
+		},
+		"AddMultipleChoiceAnswer": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Provide an answer for a multiple choice question",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the meeting the answer is associated with",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question being answered",
+				},
+				"choiceIDs": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+					Description: "The IDs of the selected choices",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				meetingID := p.Args["meetingID"].(string)
+				questionID := p.Args["questionID"].(string)
+				choiceIDs, err := getStrings(p.Args, "choiceIDs")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.NewAnswer(meetingID, impact.Answer{
+					QuestionID: questionID,
+					Type:       impact.CHOICES,
+					Answer:     choiceIDs,
+				}, u)
+			}),
+		},
+		"AddNumericAnswer": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Provide an answer for a numeric question",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the meeting the answer is associated with",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question being answered",
+				},
+				"value": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.Float),
+					Description: "The value given. Must respect the question's minimum, maximum and step",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				meetingID := p.Args["meetingID"].(string)
+				questionID := p.Args["questionID"].(string)
+				value := p.Args["value"].(float64)
+				return v.db.NewAnswer(meetingID, impact.Answer{
+					QuestionID: questionID,
+					Type:       impact.FLOAT,
+					Answer:     value,
+				}, u)
+			}),
+		},
+		"EditAnswer": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Replace an existing answer within a meeting. Completed and abandoned meetings are moved back to in progress, so they must be completed again",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the meeting the answer is associated with",
+				},
+				"answer": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(meetTypes.answerInput),
+					Description: "The new answer",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				meetingID := p.Args["meetingID"].(string)
+				answer, err := getAnswer(p.Args, "answer")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.EditAnswer(meetingID, answer, u)
+			}),
+		},
+		"DeleteAnswer": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Remove the answer to a question from a meeting. Completed and abandoned meetings are moved back to in progress, so they must be completed again",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the meeting the answer is associated with",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question whose answer should be removed",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				meetingID := p.Args["meetingID"].(string)
+				questionID := p.Args["questionID"].(string)
+				return v.db.DeleteAnswer(meetingID, questionID, u)
+			}),
+		},
+		"CompleteMeeting": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Marks a meeting as complete. Every active question in the outcome set must have been answered",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the meeting",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.CompleteMeeting(p.Args["meetingID"].(string), u)
+			}),
+		},
+		"AbandonMeeting": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Marks a meeting as abandoned, indicating it was stopped before all questions were answered",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the meeting",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.AbandonMeeting(p.Args["meetingID"].(string), u)
+			}),
+		},
+		"SetMeetingBaseline": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Marks or unmarks a meeting as the beneficiary's baseline for the meeting's outcome set. Marking a meeting unmarks the beneficiary's previous baseline meeting",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the meeting",
+				},
+				"baseline": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: true,
+					Description:  "Whether the meeting is the baseline",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.SetMeetingBaseline(p.Args["meetingID"].(string), p.Args["baseline"].(bool), u)
+			}),
+		},
+		"DeleteMeeting": &graphql.Field{
+			Type:        graphql.ID,
+			Description: "Deletes a meeting and returns the ID of the deleted meeting. Deleted meetings are excluded from queries and reports but can be restored",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the meeting",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				id := p.Args["meetingID"].(string)
+				if err := v.db.DeleteMeeting(id, u); err != nil {
+					return nil, err
+				}
+				return id, nil
+			}),
+		},
+		"RestoreMeeting": &graphql.Field{
+			Type:        meetTypes.meetingType,
+			Description: "Restores a previously deleted meeting",
+			Args: graphql.FieldConfigArgument{
+				"meetingID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the meeting",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.RestoreMeeting(p.Args["meetingID"].(string), u)
+			}),
+		},
 	}
 }
@@ -0,0 +1,31 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloatAnswerPrecision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockUser := mock.NewMockUser(mockCtrl)
+	mockDB := mock.NewMockBase(mockCtrl)
+	mockDB.EXPECT().GetMeeting("M1", mockUser).Return(impact.Meeting{
+		ID: "M1",
+		Answers: []impact.Answer{{
+			QuestionID: "Q1",
+			Type:       impact.FLOAT,
+			Answer:     72.3,
+		}},
+	}, nil)
+
+	out := postGraphQL(t, mockDB, mockUser, `{
+		meeting(id: "M1") { answers { ... on FloatAnswer { answer } } }
+	}`)
+	assert.Empty(t, out.Errors)
+	assert.JSONEq(t, `{"meeting": {"answers": [{"answer": 72.3}]}}`, string(out.Data))
+}
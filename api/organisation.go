@@ -1,27 +1,107 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/graphql-go/graphql"
+	impact "github.com/impactasaurus/server"
 	"github.com/impactasaurus/server/auth"
 )
 
 func (v *v1) initOrgTypes() organisationTypes {
-	return organisationTypes{
-		organisationType: graphql.NewObject(graphql.ObjectConfig{
-			Name:        "Organisation",
-			Description: "An organisation",
-			Fields: graphql.Fields{
-				"id": &graphql.Field{
-					Type:        graphql.NewNonNull(graphql.ID),
-					Description: "Unique identifier for the organisation",
+	ret := organisationTypes{}
+
+	ret.profileFieldTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "ProfileFieldType",
+		Description: "The kinds of value which can be stored in a profile field",
+		Values: graphql.EnumValueConfigMap{
+			string(impact.ENUMFIELD): &graphql.EnumValueConfig{
+				Value:       impact.ENUMFIELD,
+				Description: "One of the field's options",
+			},
+			string(impact.DATEFIELD): &graphql.EnumValueConfig{
+				Value:       impact.DATEFIELD,
+				Description: "A date",
+			},
+			string(impact.NUMBERFIELD): &graphql.EnumValueConfig{
+				Value:       impact.NUMBERFIELD,
+				Description: "A number",
+			},
+			string(impact.TEXTFIELD): &graphql.EnumValueConfig{
+				Value:       impact.TEXTFIELD,
+				Description: "Free text",
+			},
+		},
+	})
+
+	ret.profileFieldType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ProfileField",
+		Description: "An organisation defined piece of information which can be recorded against beneficiaries",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Unique ID",
+			},
+			"name": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The name of the field, such as gender",
+			},
+			"description": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Additional information about the field",
+			},
+			"type": &graphql.Field{
+				Type:        graphql.NewNonNull(ret.profileFieldTypeEnum),
+				Description: "The kind of value stored in the field",
+			},
+			"options": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "The values which can be selected for enum fields. Empty for other field types",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.ProfileField)
+					if !ok {
+						return nil, errors.New("Expecting an impact.ProfileField")
+					}
+					if obj.Options == nil {
+						return []string{}, nil
+					}
+					return obj.Options, nil
 				},
-				"name": &graphql.Field{
-					Type:        graphql.NewNonNull(graphql.String),
-					Description: "Organisation's name",
+			},
+		},
+	})
+
+	ret.organisationType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Organisation",
+		Description: "An organisation",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Unique identifier for the organisation",
+			},
+			"name": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Organisation's name",
+			},
+			"profileFields": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(ret.profileFieldType)),
+				Description: "The profile fields which can be recorded against the organisation's beneficiaries. Deleted fields are not included",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Organisation)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Organisation")
+					}
+					return obj.ActiveProfileFields(), nil
 				},
 			},
-		}),
-	}
+			"timeZone": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The IANA time zone used when grouping the organisation's meetings by calendar period, such as Europe/London. UTC is used if not set",
+			},
+		},
+	})
+
+	return ret
 }
 
 func (v *v1) getOrgQueries(orgTypes organisationTypes) graphql.Fields {
@@ -41,3 +121,106 @@ func (v *v1) getOrgQueries(orgTypes organisationTypes) graphql.Fields {
 		},
 	}
 }
+
+func (v *v1) getOrgMutations(orgTypes organisationTypes) graphql.Fields {
+	return graphql.Fields{
+		"AddProfileField": &graphql.Field{
+			Type:        orgTypes.profileFieldType,
+			Description: "Add a profile field which can be recorded against the organisation's beneficiaries",
+			Args: graphql.FieldConfigArgument{
+				"name": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The name of the field",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Additional information about the field",
+				},
+				"type": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(orgTypes.profileFieldTypeEnum),
+					Description: "The kind of value stored in the field",
+				},
+				"options": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "The values which can be selected, required for enum fields",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				options, err := getOptionalStrings(p.Args, "options")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.NewProfileField(
+					p.Args["name"].(string),
+					getNullableString(p.Args, "description"),
+					p.Args["type"].(impact.ProfileFieldType),
+					options,
+					u)
+			}),
+		},
+		"EditProfileField": &graphql.Field{
+			Type:        orgTypes.profileFieldType,
+			Description: "Edit a profile field. The type of the field cannot be changed",
+			Args: graphql.FieldConfigArgument{
+				"fieldID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the profile field",
+				},
+				"name": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The name of the field",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Additional information about the field",
+				},
+				"options": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "The values which can be selected, required for enum fields. Beneficiaries keep their value if an option is removed",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				options, err := getOptionalStrings(p.Args, "options")
+				if err != nil {
+					return nil, err
+				}
+				return v.db.EditProfileField(
+					p.Args["fieldID"].(string),
+					p.Args["name"].(string),
+					getNullableString(p.Args, "description"),
+					options,
+					u)
+			}),
+		},
+		"DeleteProfileField": &graphql.Field{
+			Type:        graphql.ID,
+			Description: "Deletes a profile field and returns the ID of the deleted field",
+			Args: graphql.FieldConfigArgument{
+				"fieldID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the profile field",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				id := p.Args["fieldID"].(string)
+				if err := v.db.DeleteProfileField(id, u); err != nil {
+					return nil, err
+				}
+				return id, nil
+			}),
+		},
+		"SetOrganisationTimeZone": &graphql.Field{
+			Type:        orgTypes.organisationType,
+			Description: "Sets the time zone used when grouping the organisation's meetings by calendar period",
+			Args: graphql.FieldConfigArgument{
+				"timeZone": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The IANA time zone name, such as Europe/London",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				return v.db.SetOrganisationTimeZone(p.Args["timeZone"].(string), u)
+			}),
+		},
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/graphql-go/graphql"
 	impact "github.com/impactasaurus/server"
@@ -44,19 +45,22 @@ func (v *v1) initOutcomeSetTypes(orgTypes organisationTypes) outcomeSetTypes {
 			switch obj.Type {
 			case impact.LIKERT:
 				return ret.likertScale
+			case impact.FREETEXT:
+				return ret.freeTextQuestion
+			case impact.SINGLECHOICE:
+				return ret.singleChoiceQuestion
+			case impact.MULTICHOICE:
+				return ret.multipleChoiceQuestion
+			case impact.NUMERIC:
+				return ret.numericQuestion
 			default:
 				return ret.likertScale
 			}
 		},
 	})
 
-	ret.likertScale = graphql.NewObject(graphql.ObjectConfig{
-		Name:        "LikertScale",
-		Description: "Question gathering information using Likert Scales",
-		Interfaces: []*graphql.Interface{
-			ret.questionInterface,
-		},
-		Fields: graphql.Fields{
+	questionFields := func(extra graphql.Fields) graphql.Fields {
+		fields := graphql.Fields{
 			"id": &graphql.Field{
 				Type:        graphql.NewNonNull(graphql.ID),
 				Description: "Unique ID for the question",
@@ -84,6 +88,20 @@ func (v *v1) initOutcomeSetTypes(orgTypes organisationTypes) outcomeSetTypes {
 				Type:        graphql.String,
 				Description: "The category the question belongs to",
 			},
+		}
+		for k, v := range extra {
+			fields[k] = v
+		}
+		return fields
+	}
+
+	ret.likertScale = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LikertScale",
+		Description: "Question gathering information using Likert Scales",
+		Interfaces: []*graphql.Interface{
+			ret.questionInterface,
+		},
+		Fields: questionFields(graphql.Fields{
 			"minValue": &graphql.Field{
 				Type:        graphql.Int,
 				Description: "The minimum value in the scale",
@@ -160,7 +178,138 @@ func (v *v1) initOutcomeSetTypes(orgTypes organisationTypes) outcomeSetTypes {
 					return labelStr, nil
 				},
 			},
+		}),
+	})
+
+	ret.freeTextQuestion = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FreeTextQuestion",
+		Description: "Question gathering information using free text answers",
+		Interfaces: []*graphql.Interface{
+			ret.questionInterface,
 		},
+		Fields: questionFields(graphql.Fields{}),
+	})
+
+	ret.numericQuestion = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "NumericQuestion",
+		Description: "Question gathering a number, which may be a decimal",
+		Interfaces: []*graphql.Interface{
+			ret.questionInterface,
+		},
+		Fields: questionFields(graphql.Fields{
+			"minValue": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The minimum value allowed, if any",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Question)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Question")
+					}
+					value, ok := obj.GetFloatOption("minValue")
+					if !ok {
+						return nil, nil
+					}
+					return value, nil
+				},
+			},
+			"maxValue": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The maximum value allowed, if any",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Question)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Question")
+					}
+					value, ok := obj.GetFloatOption("maxValue")
+					if !ok {
+						return nil, nil
+					}
+					return value, nil
+				},
+			},
+			"step": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The increment answers must be a multiple of, if any. Counted from the minimum value or zero",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.Question)
+					if !ok {
+						return nil, errors.New("Expecting an impact.Question")
+					}
+					value, ok := obj.GetFloatOption("step")
+					if !ok {
+						return nil, nil
+					}
+					return value, nil
+				},
+			},
+		}),
+	})
+
+	ret.choiceType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Choice",
+		Description: "An option which can be selected when answering a choice question",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Unique ID for the choice",
+			},
+			"label": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The text shown for the choice",
+			},
+			"score": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "Optional numeric score associated with the choice. Used when aggregating answers",
+			},
+			"deleted": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether the choice has been removed from the question. Removed choices are kept so existing answers can be described and scored, they should not be offered when answering",
+			},
+		},
+	})
+
+	ret.choiceInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "ChoiceInput",
+		Description: "An option which can be selected when answering a choice question",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "The ID of an existing choice. Should be omitted when adding a new choice",
+			},
+			"label": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The text shown for the choice",
+			},
+			"score": &graphql.InputObjectFieldConfig{
+				Type:        graphql.Float,
+				Description: "Optional numeric score associated with the choice. Used when aggregating answers",
+			},
+		},
+	})
+
+	choiceFields := graphql.Fields{
+		"choices": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(ret.choiceType)),
+			Description: "The choices of the question, in the order they should be displayed, including removed choices",
+		},
+	}
+
+	ret.singleChoiceQuestion = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "SingleChoiceQuestion",
+		Description: "Question answered by selecting one of the available choices",
+		Interfaces: []*graphql.Interface{
+			ret.questionInterface,
+		},
+		Fields: questionFields(choiceFields),
+	})
+
+	ret.multipleChoiceQuestion = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MultipleChoiceQuestion",
+		Description: "Question answered by selecting any number of the available choices",
+		Interfaces: []*graphql.Interface{
+			ret.questionInterface,
+		},
+		Fields: questionFields(choiceFields),
 	})
 
 	ret.aggregationEnum = graphql.NewEnum(graphql.EnumConfig{
@@ -178,6 +327,36 @@ func (v *v1) initOutcomeSetTypes(orgTypes organisationTypes) outcomeSetTypes {
 		},
 	})
 
+	ret.changeThresholdTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "ChangeThresholdType",
+		Description: "How a category's change threshold value is interpreted",
+		Values: graphql.EnumValueConfigMap{
+			string(impact.ABSOLUTETHRESHOLD): &graphql.EnumValueConfig{
+				Value:       impact.ABSOLUTETHRESHOLD,
+				Description: "The value is the smallest change in the category's aggregate which is meaningful",
+			},
+			string(impact.RCITHRESHOLD): &graphql.EnumValueConfig{
+				Value:       impact.RCITHRESHOLD,
+				Description: "The value is the reliability of the category's measure, between 0 and 1, used to calculate a reliable change index from the spread of the beneficiaries' first meetings",
+			},
+		},
+	})
+
+	changeThreshold := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ChangeThreshold",
+		Description: "How much a category's aggregate must change before a beneficiary is considered to have improved or declined",
+		Fields: graphql.Fields{
+			"type": &graphql.Field{
+				Type:        graphql.NewNonNull(ret.changeThresholdTypeEnum),
+				Description: "How the value is interpreted",
+			},
+			"value": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The absolute threshold or the reliability of the measure",
+			},
+		},
+	})
+
 	ret.categoryType = graphql.NewObject(graphql.ObjectConfig{
 		Name:        "Category",
 		Description: "Categorises a set of questions. Used for aggregation",
@@ -198,6 +377,10 @@ func (v *v1) initOutcomeSetTypes(orgTypes organisationTypes) outcomeSetTypes {
 				Type:        graphql.NewNonNull(ret.aggregationEnum),
 				Description: "The aggregation applied to the category",
 			},
+			"changeThreshold": &graphql.Field{
+				Type:        changeThreshold,
+				Description: "The threshold used to decide whether beneficiaries improved or declined. If null, any change is counted",
+			},
 		},
 	})
 
@@ -468,6 +651,47 @@ func (v *v1) getOSMutations(osTypes outcomeSetTypes) graphql.Fields {
 				return v.db.GetOutcomeSet(osID, u)
 			}),
 		},
+		"SetCategoryChangeThreshold": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Set or remove the threshold used to decide whether beneficiaries improved or declined in a category",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the outcomeset",
+				},
+				"categoryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the category",
+				},
+				"type": &graphql.ArgumentConfig{
+					Type:        osTypes.changeThresholdTypeEnum,
+					Description: "How the value is interpreted. If NULL, the category's threshold is removed",
+				},
+				"value": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "The absolute threshold or the reliability of the measure. Required if type is provided",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				outcomeSetID := p.Args["outcomeSetID"].(string)
+				categoryID := p.Args["categoryID"].(string)
+				var threshold *impact.ChangeThreshold
+				if thresholdType, ok := p.Args["type"].(impact.ChangeThresholdType); ok {
+					value, ok := getNullOrFloat(p.Args, "value")
+					if !ok {
+						return nil, errors.New("A value must be provided with the threshold type")
+					}
+					threshold = &impact.ChangeThreshold{
+						Type:  thresholdType,
+						Value: float32(value),
+					}
+				}
+				if _, err := v.db.SetCategoryChangeThreshold(outcomeSetID, categoryID, threshold, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(outcomeSetID, u)
+			}),
+		},
 		"SetCategory": &graphql.Field{
 			Type:        osTypes.outcomeSetType,
 			Description: "Set or remove the category associated with a question.",
@@ -609,6 +833,340 @@ func (v *v1) getOSMutations(osTypes outcomeSetTypes) graphql.Fields {
 				return v.db.GetOutcomeSet(osID, u)
 			}),
 		},
+		"AddFreeTextQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Add a free text question to an outcome set",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "Question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Optional description of the question",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				id := p.Args["outcomeSetID"].(string)
+				question := p.Args["question"].(string)
+				description := getNullableString(p.Args, "description")
+				if _, err := v.db.NewQuestion(id, question, description, impact.FREETEXT, map[string]interface{}{}, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(id, u)
+			}),
+		},
+		"EditFreeTextQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Edit a free text question. If arguments are not specified, their values are not altered.",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "The new question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "New description of the question",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				osID := p.Args["outcomeSetID"].(string)
+				qID := p.Args["questionID"].(string)
+				originalQ, err := v.db.GetQuestion(osID, qID, u)
+				if err != nil {
+					return nil, err
+				}
+				if originalQ.Type != impact.FREETEXT {
+					return nil, errors.New("Question is not a free text question")
+				}
+				newQ := originalQ
+
+				if newQuestion, ok := getNullOrString(p.Args, "question"); ok {
+					newQ.Question = newQuestion
+				}
+				if newDescription, ok := getNullOrString(p.Args, "description"); ok {
+					newQ.Description = newDescription
+				}
+				if _, err := v.db.EditQuestion(osID, qID, newQ.Question, newQ.Description, impact.FREETEXT, newQ.Options, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(osID, u)
+			}),
+		},
+		"AddSingleChoiceQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Add a single choice question to an outcome set",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "Question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Optional description of the question",
+				},
+				"choices": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(osTypes.choiceInput))),
+					Description: "The choices available, in the order they should be displayed",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				id := p.Args["outcomeSetID"].(string)
+				question := p.Args["question"].(string)
+				description := getNullableString(p.Args, "description")
+				choices, err := getChoices(p.Args, "choices")
+				if err != nil {
+					return nil, err
+				}
+				if _, err := v.db.NewChoiceQuestion(id, question, description, impact.SINGLECHOICE, choices, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(id, u)
+			}),
+		},
+		"AddMultipleChoiceQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Add a multiple choice question to an outcome set",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "Question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Optional description of the question",
+				},
+				"choices": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(osTypes.choiceInput))),
+					Description: "The choices available, in the order they should be displayed",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				id := p.Args["outcomeSetID"].(string)
+				question := p.Args["question"].(string)
+				description := getNullableString(p.Args, "description")
+				choices, err := getChoices(p.Args, "choices")
+				if err != nil {
+					return nil, err
+				}
+				if _, err := v.db.NewChoiceQuestion(id, question, description, impact.MULTICHOICE, choices, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(id, u)
+			}),
+		},
+		"EditChoiceQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Edit a single or multiple choice question. If arguments are not specified, their values are not altered.",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "The new question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "New description of the question",
+				},
+				"choices": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(osTypes.choiceInput)),
+					Description: "The new list of choices, in the order they should be displayed. Existing choices should be identified by their ID, choices without an ID are added. Existing choices which are not included are marked as deleted. The score of an existing choice cannot be removed.",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				osID := p.Args["outcomeSetID"].(string)
+				qID := p.Args["questionID"].(string)
+				originalQ, err := v.db.GetQuestion(osID, qID, u)
+				if err != nil {
+					return nil, err
+				}
+				if !originalQ.IsChoice() {
+					return nil, errors.New("Question is not a choice question")
+				}
+				newQ := originalQ
+
+				if newQuestion, ok := getNullOrString(p.Args, "question"); ok {
+					newQ.Question = newQuestion
+				}
+				if newDescription, ok := getNullOrString(p.Args, "description"); ok {
+					newQ.Description = newDescription
+				}
+				if _, ok := p.Args["choices"]; ok {
+					newChoices, err := getChoices(p.Args, "choices")
+					if err != nil {
+						return nil, err
+					}
+					newQ.Choices, err = originalQ.EditChoices(newChoices)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if _, err := v.db.EditChoiceQuestion(osID, qID, newQ.Question, newQ.Description, newQ.Choices, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(osID, u)
+			}),
+		},
+		"AddNumericQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Add a numeric question to an outcome set",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "Question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Optional description of the question",
+				},
+				"minValue": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "Optional minimum value allowed",
+				},
+				"maxValue": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "Optional maximum value allowed",
+				},
+				"step": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "Optional increment answers must be a multiple of, counted from the minimum value or zero. For example, 0.5 allows halves",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				id := p.Args["outcomeSetID"].(string)
+				question := p.Args["question"].(string)
+				description := getNullableString(p.Args, "description")
+				options := map[string]interface{}{}
+				for _, k := range numericOptions {
+					if f, ok := getNullOrFloat(p.Args, k); ok {
+						options[k] = f
+					}
+				}
+				if err := checkNumericOptions(options); err != nil {
+					return nil, err
+				}
+				if _, err := v.db.NewQuestion(id, question, description, impact.NUMERIC, options, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(id, u)
+			}),
+		},
+		"EditNumericQuestion": &graphql.Field{
+			Type:        osTypes.outcomeSetType,
+			Description: "Edit a numeric question. If arguments are not specified, their values are not altered. Use clear to remove the minValue, maxValue or step.",
+			Args: graphql.FieldConfigArgument{
+				"outcomeSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.ID),
+					Description: "The ID of the outcomeset",
+				},
+				"questionID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The ID of the question",
+				},
+				"question": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "The new question to be asked",
+				},
+				"description": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "New description of the question",
+				},
+				"minValue": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "New minimum value allowed",
+				},
+				"maxValue": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "New maximum value allowed",
+				},
+				"step": &graphql.ArgumentConfig{
+					Type:        graphql.Float,
+					Description: "New increment answers must be a multiple of",
+				},
+				"clear": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Options to remove from the question, any of minValue, maxValue and step. An option cannot be set and cleared in the same edit",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				osID := p.Args["outcomeSetID"].(string)
+				qID := p.Args["questionID"].(string)
+				originalQ, err := v.db.GetQuestion(osID, qID, u)
+				if err != nil {
+					return nil, err
+				}
+				if originalQ.Type != impact.NUMERIC {
+					return nil, errors.New("Question is not a numeric question")
+				}
+				newQ := originalQ
+				if newQ.Options == nil {
+					newQ.Options = map[string]interface{}{}
+				}
+
+				if newQuestion, ok := getNullOrString(p.Args, "question"); ok {
+					newQ.Question = newQuestion
+				}
+				if newDescription, ok := getNullOrString(p.Args, "description"); ok {
+					newQ.Description = newDescription
+				}
+				for _, k := range numericOptions {
+					if f, ok := getNullOrFloat(p.Args, k); ok {
+						newQ.Options[k] = f
+					}
+				}
+				clear, err := getOptionalStrings(p.Args, "clear")
+				if err != nil {
+					return nil, err
+				}
+				for _, k := range clear {
+					if !isNumericOption(k) {
+						return nil, fmt.Errorf("%s is not a numeric question option", k)
+					}
+					if _, ok := getNullOrFloat(p.Args, k); ok {
+						return nil, fmt.Errorf("%s cannot be set and cleared in the same edit", k)
+					}
+					delete(newQ.Options, k)
+				}
+				if err := checkNumericOptions(newQ.Options); err != nil {
+					return nil, err
+				}
+				if _, err := v.db.EditQuestion(osID, qID, newQ.Question, newQ.Description, impact.NUMERIC, newQ.Options, u); err != nil {
+					return nil, err
+				}
+				return v.db.GetOutcomeSet(osID, u)
+			}),
+		},
 		"DeleteQuestion": &graphql.Field{
 			Type:        osTypes.outcomeSetType,
 			Description: "Remove a question from an outcome set",
@@ -633,3 +1191,28 @@ func (v *v1) getOSMutations(osTypes outcomeSetTypes) graphql.Fields {
 		},
 	}
 }
+
+// numericOptions are the options of a numeric question which can be set and cleared
+var numericOptions = []string{"minValue", "maxValue", "step"}
+
+func isNumericOption(key string) bool {
+	for _, k := range numericOptions {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func checkNumericOptions(options map[string]interface{}) error {
+	q := impact.Question{Options: options}
+	min, hasMin := q.GetFloatOption("minValue")
+	max, hasMax := q.GetFloatOption("maxValue")
+	if hasMin && hasMax && min > max {
+		return errors.New("minValue must be less than or equal to maxValue")
+	}
+	if step, ok := q.GetFloatOption("step"); ok && step <= 0 {
+		return errors.New("step must be greater than zero")
+	}
+	return nil
+}
@@ -1,16 +1,18 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/graphql-go/graphql"
+	impact "github.com/impactasaurus/server"
 	"github.com/impactasaurus/server/auth"
 	"github.com/impactasaurus/server/logic"
 )
 
-func (v *v1) initRepTypes() reportTypes {
+func (v *v1) initRepTypes(meetTypes meetingTypes) reportTypes {
 
 	excluded := graphql.NewObject(graphql.ObjectConfig{
 		Name:        "Excluded",
@@ -31,6 +33,151 @@ func (v *v1) initRepTypes() reportTypes {
 		},
 	})
 
+	baselineEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "BaselineStrategy",
+		Description: "Determines which of a beneficiary's meetings is compared with their last meeting",
+		Values: graphql.EnumValueConfigMap{
+			string(impact.FIRSTMEETING): &graphql.EnumValueConfig{
+				Value:       impact.FIRSTMEETING,
+				Description: "The beneficiary's first ever meeting",
+			},
+			string(impact.FIRSTINRANGE): &graphql.EnumValueConfig{
+				Value:       impact.FIRSTINRANGE,
+				Description: "The beneficiary's first meeting within the report's date range",
+			},
+			string(impact.MARKEDBASELINE): &graphql.EnumValueConfig{
+				Value:       impact.MARKEDBASELINE,
+				Description: "The meeting marked as the beneficiary's baseline. Beneficiaries without a baseline meeting are excluded",
+			},
+			string(impact.CLOSESTTODATE): &graphql.EnumValueConfig{
+				Value:       impact.CLOSESTTODATE,
+				Description: "The beneficiary's meeting conducted closest to the baseline date",
+			},
+		},
+	})
+
+	filters := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "JOCFilters",
+		Description: "Details the filters used to select the beneficiaries and meetings included in a report",
+		Fields: graphql.Fields{
+			"includeTags": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "Only beneficiaries with at least one of these tags were included",
+			},
+			"excludeTags": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "Beneficiaries with any of these tags were not included",
+			},
+			"completedOnly": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether only completed meetings were considered",
+			},
+			"baseline": &graphql.Field{
+				Type:        graphql.NewNonNull(baselineEnum),
+				Description: "How each beneficiary's baseline meeting was selected",
+			},
+			"baselineDate": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The date used to select baseline meetings. Only provided for the closest to date strategy",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					obj, ok := p.Source.(impact.JOCFilters)
+					if !ok {
+						return nil, errors.New("Expecting an impact.JOCFilters")
+					}
+					if obj.BaselineDate == nil {
+						return nil, nil
+					}
+					return obj.BaselineDate.Format(time.RFC3339), nil
+				},
+			},
+		},
+	})
+
+	histogramBin := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "HistogramBin",
+		Description: "Counts the values between min (inclusive) and max (exclusive). The last bin of a histogram also includes values equal to its max",
+		Fields: graphql.Fields{
+			"min": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The lower bound of the bin",
+			},
+			"max": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The upper bound of the bin",
+			},
+			"count": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of values within the bin",
+			},
+		},
+	})
+
+	significance := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "DeltaSignificance",
+		Description: "Describes whether the change between the beneficiaries' first and last meetings is statistically meaningful. Values which could not be calculated are null",
+		Fields: graphql.Fields{
+			"pairedTTestP": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The two sided p-value of a paired t-test on the changes",
+			},
+			"wilcoxonP": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The two sided p-value of a Wilcoxon signed-rank test on the changes",
+			},
+			"cohensD": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "Cohen's d effect size, the mean change divided by the standard deviation of the changes",
+			},
+			"ciLower": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The lower bound of the 95% confidence interval for the mean change",
+			},
+			"ciUpper": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The upper bound of the 95% confidence interval for the mean change",
+			},
+			"warnings": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "Explains why values could not be calculated and flags samples too small to be reliable",
+			},
+		},
+	})
+
+	changeCounts := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ChangeCounts",
+		Description: "Counts the beneficiaries whose value went up, stayed the same or went down. A change must be larger than the threshold to count as an improvement or decline",
+		Fields: graphql.Fields{
+			"threshold": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The change a beneficiary's value had to exceed. Zero if the category has no threshold. Thresholds are only applied to categories",
+			},
+			"improved": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of beneficiaries whose value went up by more than the threshold",
+			},
+			"unchanged": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of beneficiaries whose value changed by no more than the threshold",
+			},
+			"declined": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of beneficiaries whose value went down by more than the threshold",
+			},
+			"improvedPercent": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The percentage of beneficiaries who improved",
+			},
+			"unchangedPercent": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The percentage of beneficiaries who were unchanged",
+			},
+			"declinedPercent": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The percentage of beneficiaries who declined",
+			},
+		},
+	})
+
 	jocAggregate := func(typeName string) *graphql.Object {
 		lcTypeName := strings.ToLower(typeName)
 		return graphql.NewObject(graphql.ObjectConfig{
@@ -45,6 +192,42 @@ func (v *v1) initRepTypes() reportTypes {
 					Type:        graphql.Float,
 					Description: "The aggregated value",
 				},
+				"count": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "The number of beneficiary values aggregated",
+				},
+				"median": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "The median of the beneficiary values",
+				},
+				"stdDev": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "The sample standard deviation of the beneficiary values. Zero if there are fewer than two values",
+				},
+				"min": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "The smallest beneficiary value",
+				},
+				"max": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "The largest beneficiary value",
+				},
+				"iqr": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "The interquartile range of the beneficiary values",
+				},
+				"histogram": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(histogramBin)),
+					Description: "The beneficiary values counted into equal width bins spanning the smallest to the largest value",
+				},
+				"significance": &graphql.Field{
+					Type:        significance,
+					Description: "Whether the change is statistically meaningful. Only provided for deltas",
+				},
+				"change": &graphql.Field{
+					Type:        changeCounts,
+					Description: "How many beneficiaries improved, were unchanged or declined. Only provided for deltas",
+				},
 				"beneficiaryIDs": &graphql.Field{
 					Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
 					Description: "The beneficiary IDs included in the aggregation",
@@ -78,7 +261,341 @@ func (v *v1) initRepTypes() reportTypes {
 		})
 	}
 
+	questionAggregate := jocAggregate("Question")
+	categoryAggregate := jocAggregate("Category")
+	questionAggregates := jocAggregates("Question", questionAggregate)
+	categoryAggregates := jocAggregates("Category", categoryAggregate)
+
+	breakdownGroup := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "JOCBreakdownGroup",
+		Description: "Journey of change aggregates for the beneficiaries sharing a value for the breakdown's profile field",
+		Fields: graphql.Fields{
+			"value": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The profile field value shared by the group. Dates are grouped by year. Empty for beneficiaries without a value",
+			},
+			"beneficiaryIDs": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "The beneficiary IDs included in the group",
+			},
+			"questionAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(questionAggregates),
+				Description: "Questions aggregated over the group's beneficiaries",
+			},
+			"categoryAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(categoryAggregates),
+				Description: "Categories aggregated over the group's beneficiaries",
+			},
+			"excluded": &graphql.Field{
+				Type:        excluded,
+				Description: "Details the questions and categories which could not be aggregated for the group due to lack of data",
+			},
+		},
+	})
+
+	breakdown := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "JOCBreakdown",
+		Description: "Splits a journey of change report by the beneficiaries' values for a profile field",
+		Fields: graphql.Fields{
+			"fieldID": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ID of the profile field used to group the beneficiaries",
+			},
+			"groups": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(breakdownGroup)),
+				Description: "The groups of beneficiaries",
+			},
+		},
+	})
+
+	benValue := func(typeName string) *graphql.Object {
+		lcTypeName := strings.ToLower(typeName)
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name:        fmt.Sprintf("Beneficiary%sValue", typeName),
+			Description: fmt.Sprintf("A beneficiary's %s value in a single meeting and how it has changed", lcTypeName),
+			Fields: graphql.Fields{
+				fmt.Sprintf("%sID", lcTypeName): &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: fmt.Sprintf("The ID of the %s", lcTypeName),
+				},
+				"value": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Float),
+					Description: "The value in this meeting",
+				},
+				"fromFirst": &graphql.Field{
+					Type:        graphql.Float,
+					Description: fmt.Sprintf("The change since the first meeting with a value for the %s. Null if this is that meeting", lcTypeName),
+				},
+				"fromPrevious": &graphql.Field{
+					Type:        graphql.Float,
+					Description: fmt.Sprintf("The change since the previous meeting with a value for the %s. Null if there is no previous meeting", lcTypeName),
+				},
+			},
+		})
+	}
+
+	benReportMeeting := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "BeneficiaryReportMeeting",
+		Description: "A single meeting in a beneficiary's journey",
+		Fields: graphql.Fields{
+			"meeting": &graphql.Field{
+				Type:        graphql.NewNonNull(meetTypes.meetingType),
+				Description: "The meeting",
+			},
+			"questions": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(benValue("Question"))),
+				Description: "The numeric answers given in the meeting",
+			},
+			"categories": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(benValue("Category"))),
+				Description: "The category aggregates of the meeting",
+			},
+		},
+	})
+
+	trendPeriodEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "TrendPeriod",
+		Description: "The calendar period used to group meetings in a trend report",
+		Values: graphql.EnumValueConfigMap{
+			string(impact.WEEK): &graphql.EnumValueConfig{
+				Value:       impact.WEEK,
+				Description: "Weeks starting on Monday",
+			},
+			string(impact.MONTH): &graphql.EnumValueConfig{
+				Value:       impact.MONTH,
+				Description: "Calendar months",
+			},
+			string(impact.QUARTER): &graphql.EnumValueConfig{
+				Value:       impact.QUARTER,
+				Description: "Calendar quarters, starting in January, April, July and October",
+			},
+			string(impact.FINANCIALYEAR): &graphql.EnumValueConfig{
+				Value:       impact.FINANCIALYEAR,
+				Description: "Financial years, starting in the configured month",
+			},
+		},
+	})
+
+	bucketTime := func(get func(impact.TrendBucket) time.Time) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			obj, ok := p.Source.(impact.TrendBucket)
+			if !ok {
+				return nil, errors.New("Expecting an impact.TrendBucket")
+			}
+			return get(obj).Format(time.RFC3339), nil
+		}
+	}
+
+	trendBucket := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TrendBucket",
+		Description: "Aggregates the meetings conducted within a calendar period. Each beneficiary is represented by their latest meeting within the period",
+		Fields: graphql.Fields{
+			"start": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The start of the period, inclusive",
+				Resolve: bucketTime(func(b impact.TrendBucket) time.Time {
+					return b.Start
+				}),
+			},
+			"end": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The end of the period, exclusive",
+				Resolve: bucketTime(func(b impact.TrendBucket) time.Time {
+					return b.End
+				}),
+			},
+			"label": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "A name for the period, such as 2017-W03, 2017-01, 2017-Q1 or 2017/18",
+			},
+			"meetingCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of meetings conducted within the period",
+			},
+			"beneficiaryCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of beneficiaries with a meeting within the period",
+			},
+			"questionAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(questionAggregate)),
+				Description: "Questions aggregated over the period's beneficiaries. Questions without answers in the period are not included",
+			},
+			"categoryAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(categoryAggregate)),
+				Description: "Categories aggregated over the period's beneficiaries. Categories without answers in the period are not included",
+			},
+		},
+	})
+
+	visitStep := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "VisitStep",
+		Description: "Aggregates the beneficiaries' nth meetings",
+		Fields: graphql.Fields{
+			"visit": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The meeting number, starting at 1 for the beneficiaries' first meetings",
+			},
+			"beneficiaryCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of beneficiaries who had at least this many meetings",
+			},
+			"retainedPercent": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The percentage of the report's beneficiaries who had at least this many meetings",
+			},
+			"questionAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(questionAggregate)),
+				Description: "Questions aggregated over the beneficiaries' nth meetings",
+			},
+			"categoryAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(categoryAggregate)),
+				Description: "Categories aggregated over the beneficiaries' nth meetings",
+			},
+		},
+	})
+
+	attritionGroup := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "AttritionGroup",
+		Description: "The baseline aggregates of a group of beneficiaries",
+		Fields: graphql.Fields{
+			"beneficiaryIDs": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+				Description: "The beneficiaries in the group",
+			},
+			"questionAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(questionAggregate)),
+				Description: "Questions aggregated over the beneficiaries' first meetings",
+			},
+			"categoryAggregates": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(categoryAggregate)),
+				Description: "Categories aggregated over the beneficiaries' first meetings",
+			},
+		},
+	})
+
 	return reportTypes{
+		BaselineEnum:    baselineEnum,
+		TrendPeriodEnum: trendPeriodEnum,
+		AttritionType: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "AttritionReport",
+			Description: "This report details how many beneficiaries remained engaged after starting a question set.",
+			Fields: graphql.Fields{
+				"questionSetID": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set the report is about",
+				},
+				"started": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "The number of beneficiaries whose first meeting was within the date range",
+				},
+				"followedUp": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "The number of those beneficiaries who had more than one meeting",
+				},
+				"followUpPercent": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Float),
+					Description: "The percentage of beneficiaries who had more than one meeting",
+				},
+				"medianDaysBetweenMeetings": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "The median number of days between a beneficiary's consecutive meetings. Not provided if no beneficiary had more than one meeting",
+				},
+				"inactiveDays": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "How many days a beneficiary must go without a meeting to be considered inactive",
+				},
+				"inactive": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "The number of beneficiaries who have not had a meeting within the inactivity period",
+				},
+				"inactivePercent": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Float),
+					Description: "The percentage of beneficiaries who are inactive",
+				},
+				"droppedOut": &graphql.Field{
+					Type:        graphql.NewNonNull(attritionGroup),
+					Description: "The baseline aggregates of the inactive beneficiaries",
+				},
+				"retained": &graphql.Field{
+					Type:        graphql.NewNonNull(attritionGroup),
+					Description: "The baseline aggregates of the beneficiaries who remain active",
+				},
+			},
+		}),
+		VisitType: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "VisitReport",
+			Description: "This report aggregates beneficiaries' answers by meeting number, regardless of when the meetings were conducted.",
+			Fields: graphql.Fields{
+				"questionSetID": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set the report is about",
+				},
+				"beneficiaryIDs": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+					Description: "The beneficiary IDs included in the report",
+				},
+				"steps": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(visitStep)),
+					Description: "The aggregates of the beneficiaries' first meetings, followed by their second meetings and so on",
+				},
+				"warnings": &graphql.Field{
+					Type:        graphql.NewList(graphql.String),
+					Description: "Any warning messages associated with the report.",
+				},
+			},
+		}),
+		TrendType: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "TrendReport",
+			Description: "This report details how aggregates move over consecutive calendar periods.",
+			Fields: graphql.Fields{
+				"questionSetID": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set the report is about",
+				},
+				"period": &graphql.Field{
+					Type:        graphql.NewNonNull(trendPeriodEnum),
+					Description: "The calendar period used to group meetings",
+				},
+				"financialYearStart": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.Int),
+					Description: "The month, 1 to 12, in which financial years start",
+				},
+				"timeZone": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The time zone the periods were calculated in",
+				},
+				"buckets": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(trendBucket)),
+					Description: "The periods, in chronological order. Periods without meetings are included",
+				},
+				"warnings": &graphql.Field{
+					Type:        graphql.NewList(graphql.String),
+					Description: "Any warning messages associated with the report.",
+				},
+			},
+		}),
+		BeneficiaryType: graphql.NewObject(graphql.ObjectConfig{
+			Name:        "BeneficiaryReport",
+			Description: "This report details how an individual beneficiary's answers have changed over time.",
+			Fields: graphql.Fields{
+				"beneficiaryID": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The beneficiary the report is about",
+				},
+				"questionSetID": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set the report is about",
+				},
+				"meetings": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(benReportMeeting)),
+					Description: "The beneficiary's meetings, ordered by when they were conducted",
+				},
+				"warnings": &graphql.Field{
+					Type:        graphql.NewList(graphql.String),
+					Description: "Any warning messages associated with the report.",
+				},
+			},
+		}),
 		JOCType: graphql.NewObject(graphql.ObjectConfig{
 			Name:        "JOCServiceReport",
 			Description: "This report details journey of change results aggregated across multiple beneficiaries.",
@@ -88,17 +605,25 @@ func (v *v1) initRepTypes() reportTypes {
 					Description: "The beneficiary IDs included in the report",
 				},
 				"questionAggregates": &graphql.Field{
-					Type:        graphql.NewNonNull(jocAggregates("Question", jocAggregate("Question"))),
+					Type:        graphql.NewNonNull(questionAggregates),
 					Description: "Questions aggregated over multiple beneficiaries",
 				},
 				"categoryAggregates": &graphql.Field{
-					Type:        graphql.NewNonNull(jocAggregates("Category", jocAggregate("Category"))),
+					Type:        graphql.NewNonNull(categoryAggregates),
 					Description: "Questions aggregated over multiple beneficiaries",
 				},
 				"excluded": &graphql.Field{
 					Type:        excluded,
 					Description: "Details the questions, categories and beneficiaries excluded from the report due to lack of data rather than error",
 				},
+				"breakdown": &graphql.Field{
+					Type:        breakdown,
+					Description: "The aggregates split by a profile field. Only provided if a breakdown field was requested",
+				},
+				"filters": &graphql.Field{
+					Type:        graphql.NewNonNull(filters),
+					Description: "The filters applied when selecting the beneficiaries and meetings included in the report",
+				},
 				"warnings": &graphql.Field{
 					Type:        graphql.NewList(graphql.String),
 					Description: "Any warning messages associated with the report.",
@@ -115,7 +640,8 @@ func (v *v1) getRepQueries(repTypes reportTypes) graphql.Fields {
 			Description: `Produces a journey of change report for the organisation between two dates.
 This will aggregate questions and categories across multiple beneficiaries.
 Beneficiaries with meetings (belonging to the provided question set) within the provided date range will be included in the report.
-For each beneficiary, their first meeting (does not have to be in the provided date range) and their last meeting within the provided date range are compared.
+For each beneficiary, a baseline meeting and their last meeting within the provided date range are compared.
+By default the baseline is their first meeting, which does not have to be in the provided date range. The baseline argument selects other strategies.
 Aggregates are calculated over all beneficiaries for the first and last meetings, as well as the difference between them.
 `,
 			Args: graphql.FieldConfigArgument{
@@ -131,6 +657,40 @@ Aggregates are calculated over all beneficiaries for the first and last meetings
 					Type:        graphql.NewNonNull(graphql.String),
 					Description: "The question set to produce the report for",
 				},
+				"completedOnly": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Only consider completed meetings when selecting each beneficiary's first and last meetings. In progress and abandoned meetings are ignored",
+				},
+				"includeTags": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Only include beneficiaries with at least one of these tags",
+				},
+				"excludeTags": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+					Description: "Do not include beneficiaries with any of these tags",
+				},
+				"baseline": &graphql.ArgumentConfig{
+					Type:         repTypes.BaselineEnum,
+					DefaultValue: impact.FIRSTMEETING,
+					Description:  "How each beneficiary's baseline meeting, which is compared with their last meeting in the date range, is selected",
+				},
+				"baselineDate": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Required when the baseline strategy is closestToDate. Should be ISO standard timestamp",
+				},
+				"breakdownFieldID": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "The ID of a beneficiary profile field. If provided, the aggregates are also split by the beneficiaries' values for the field",
+				},
+				"breakdownBands": &graphql.ArgumentConfig{
+					Type:        graphql.NewList(graphql.NewNonNull(graphql.Float)),
+					Description: "Ascending boundaries splitting a number breakdown field, or the ages in years of a date breakdown field, into bands. For example, [18, 25, 65] gives under 18, 18 to under 25, 25 to under 65 and 65 and over. Ages are measured at the end of the report. Without bands, number fields are split by value and date fields by year",
+				},
+				"breakdownPrefixLength": &graphql.ArgumentConfig{
+					Type:        graphql.Int,
+					Description: "Groups a text breakdown field by its first characters, ignoring case and spaces. For example, 2 groups UK postcodes by their first two characters",
+				},
 			},
 			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
 				start := p.Args["start"].(string)
@@ -144,7 +704,203 @@ Aggregates are calculated over all beneficiaries for the first and last meetings
 					return nil, err
 				}
 				osID := p.Args["questionSetID"].(string)
-				return logic.GetJOCServiceReport(startParsed, endParsed, osID, v.db, u)
+				opts := logic.JOCOptions{
+					CompletedOnly:         p.Args["completedOnly"].(bool),
+					BreakdownFieldID:      getNullableString(p.Args, "breakdownFieldID"),
+					BreakdownPrefixLength: getNullableInt(p.Args, "breakdownPrefixLength"),
+				}
+				if opts.BreakdownBands, err = getOptionalFloats(p.Args, "breakdownBands"); err != nil {
+					return nil, err
+				}
+				if opts.IncludeTags, err = getOptionalStrings(p.Args, "includeTags"); err != nil {
+					return nil, err
+				}
+				if opts.ExcludeTags, err = getOptionalStrings(p.Args, "excludeTags"); err != nil {
+					return nil, err
+				}
+				if baseline, ok := p.Args["baseline"].(impact.BaselineStrategy); ok {
+					opts.Baseline = baseline
+				}
+				if opts.BaselineDate, err = getNullableTime(p.Args, "baselineDate"); err != nil {
+					return nil, err
+				}
+				return logic.GetJOCServiceReport(startParsed, endParsed, osID, opts, v.db, u)
+			}),
+		},
+		"BeneficiaryReport": &graphql.Field{
+			Type: repTypes.BeneficiaryType,
+			Description: `Produces a report detailing an individual beneficiary's journey through a question set.
+Each of the beneficiary's meetings is included with its numeric answers and category aggregates,
+along with how they have changed since the beneficiary's first meeting and their previous meeting.
+`,
+			Args: graphql.FieldConfigArgument{
+				"beneficiaryID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The beneficiary to produce the report for",
+				},
+				"questionSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set to produce the report for",
+				},
+				"completedOnly": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Only include completed meetings. In progress and abandoned meetings are ignored",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				benID := p.Args["beneficiaryID"].(string)
+				osID := p.Args["questionSetID"].(string)
+				completedOnly := p.Args["completedOnly"].(bool)
+				return logic.GetBeneficiaryReport(benID, osID, completedOnly, v.db, u)
+			}),
+		},
+		"TrendReport": &graphql.Field{
+			Type: repTypes.TrendType,
+			Description: `Produces a report showing how a question set's aggregates move over time.
+Meetings within the provided date range are grouped by calendar period, calculated in the organisation's time zone.
+Each beneficiary is represented by their latest meeting within a period.
+`,
+			Args: graphql.FieldConfigArgument{
+				"start": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The start of the report. Should be ISO standard timestamp",
+				},
+				"end": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The end of the report. Should be ISO standard timestamp",
+				},
+				"questionSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set to produce the report for",
+				},
+				"period": &graphql.ArgumentConfig{
+					Type:         repTypes.TrendPeriodEnum,
+					DefaultValue: impact.MONTH,
+					Description:  "The calendar period used to group meetings",
+				},
+				"financialYearStart": &graphql.ArgumentConfig{
+					Type:         graphql.Int,
+					DefaultValue: 4,
+					Description:  "The month, 1 to 12, in which financial years start. Only used with the financialYear period",
+				},
+				"completedOnly": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Only include completed meetings. In progress and abandoned meetings are ignored",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				start, err := time.Parse(time.RFC3339, p.Args["start"].(string))
+				if err != nil {
+					return nil, err
+				}
+				end, err := time.Parse(time.RFC3339, p.Args["end"].(string))
+				if err != nil {
+					return nil, err
+				}
+				opts := logic.TrendOptions{
+					FinancialYearStart: p.Args["financialYearStart"].(int),
+					CompletedOnly:      p.Args["completedOnly"].(bool),
+				}
+				if period, ok := p.Args["period"].(impact.TrendPeriod); ok {
+					opts.Period = period
+				}
+				return logic.GetTrendReport(start, end, p.Args["questionSetID"].(string), opts, v.db, u)
+			}),
+		},
+		"VisitReport": &graphql.Field{
+			Type: repTypes.VisitType,
+			Description: `Produces a report aggregating beneficiaries' answers by meeting number.
+Beneficiaries with meetings (belonging to the provided question set) within the provided date range will be included in the report.
+All of their meetings are ordered, including those outside of the date range, and their first meetings are aggregated together, followed by their second meetings and so on.
+The beneficiary count of each step shows how many beneficiaries reached that meeting.
+`,
+			Args: graphql.FieldConfigArgument{
+				"start": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The start of the period to consider when searching for beneficiaries to include in the report. Should be ISO standard timestamp",
+				},
+				"end": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The end of the period to consider when searching for beneficiaries to include in the report. Should be ISO standard timestamp",
+				},
+				"questionSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set to produce the report for",
+				},
+				"completedOnly": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Only include completed meetings. In progress and abandoned meetings do not count as a visit",
+				},
+				"maxVisits": &graphql.ArgumentConfig{
+					Type:        graphql.Int,
+					Description: "Limits the number of steps in the report. All visits are included if not provided",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				start, err := time.Parse(time.RFC3339, p.Args["start"].(string))
+				if err != nil {
+					return nil, err
+				}
+				end, err := time.Parse(time.RFC3339, p.Args["end"].(string))
+				if err != nil {
+					return nil, err
+				}
+				opts := logic.VisitOptions{
+					CompletedOnly: p.Args["completedOnly"].(bool),
+				}
+				if maxVisits, ok := p.Args["maxVisits"].(int); ok {
+					opts.MaxVisits = maxVisits
+				}
+				return logic.GetVisitReport(start, end, p.Args["questionSetID"].(string), opts, v.db, u)
+			}),
+		},
+		"AttritionReport": &graphql.Field{
+			Type: repTypes.AttritionType,
+			Description: `Produces a report detailing beneficiary engagement and drop out.
+Beneficiaries whose first meeting (belonging to the provided question set) was within the provided date range will be included in the report.
+Beneficiaries who have not had a meeting within the inactivity period are considered to have dropped out.
+`,
+			Args: graphql.FieldConfigArgument{
+				"start": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The start of the period to consider when searching for beneficiaries' first meetings. Should be ISO standard timestamp",
+				},
+				"end": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The end of the period to consider when searching for beneficiaries' first meetings. Should be ISO standard timestamp",
+				},
+				"questionSetID": &graphql.ArgumentConfig{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "The question set to produce the report for",
+				},
+				"completedOnly": &graphql.ArgumentConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: false,
+					Description:  "Only include completed meetings. In progress and abandoned meetings are ignored",
+				},
+				"inactiveDays": &graphql.ArgumentConfig{
+					Type:         graphql.Int,
+					DefaultValue: 90,
+					Description:  "How many days a beneficiary must go without a meeting to be considered inactive",
+				},
+			},
+			Resolve: userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+				start, err := time.Parse(time.RFC3339, p.Args["start"].(string))
+				if err != nil {
+					return nil, err
+				}
+				end, err := time.Parse(time.RFC3339, p.Args["end"].(string))
+				if err != nil {
+					return nil, err
+				}
+				opts := logic.AttritionOptions{
+					CompletedOnly: p.Args["completedOnly"].(bool),
+					InactiveDays:  p.Args["inactiveDays"].(int),
+				}
+				return logic.GetAttritionReport(start, end, p.Args["questionSetID"].(string), opts, v.db, u)
 			}),
 		},
 	}
@@ -18,9 +18,10 @@ func combineFields(toCombine ...graphql.Fields) (graphql.Fields, error) {
 	return final, nil
 }
 
-func (v *v1) getSchema(orgTypes organisationTypes, osTypes outcomeSetTypes, meetTypes meetingTypes, repTypes reportTypes) (*graphql.Schema, error) {
+func (v *v1) getSchema(orgTypes organisationTypes, osTypes outcomeSetTypes, meetTypes meetingTypes, benTypes beneficiaryTypes, repTypes reportTypes) (*graphql.Schema, error) {
 	queries, err := combineFields(
 		v.getMeetingQueries(meetTypes),
+		v.getBeneficiaryQueries(benTypes),
 		v.getOrgQueries(orgTypes),
 		v.getOSQueries(osTypes),
 		v.getRepQueries(repTypes),
@@ -35,7 +36,9 @@ func (v *v1) getSchema(orgTypes organisationTypes, osTypes outcomeSetTypes, meet
 
 	mutations, err := combineFields(
 		v.getOSMutations(osTypes),
+		v.getOrgMutations(orgTypes),
 		v.getMeetingMutations(meetTypes),
+		v.getBeneficiaryMutations(benTypes),
 	)
 
 	mutationType := graphql.NewObject(graphql.ObjectConfig{
@@ -48,7 +51,15 @@ func (v *v1) getSchema(orgTypes organisationTypes, osTypes outcomeSetTypes, meet
 		Mutation: mutationType,
 		Types: []graphql.Type{
 			osTypes.likertScale,
+			osTypes.freeTextQuestion,
+			osTypes.singleChoiceQuestion,
+			osTypes.multipleChoiceQuestion,
+			osTypes.numericQuestion,
 			meetTypes.intAnswer,
+			meetTypes.stringAnswer,
+			meetTypes.choiceAnswer,
+			meetTypes.choicesAnswer,
+			meetTypes.floatAnswer,
 		},
 	})
 	if err != nil {
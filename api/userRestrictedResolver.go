@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/graphql-go/graphql"
 	"github.com/impactasaurus/server/auth"
 )
@@ -15,4 +17,14 @@ func userRestrictedResolver(fn userAuthenticatedResolver) graphql.FieldResolveFn
 		}
 		return fn(p, u)
 	}
-}
\ No newline at end of file
+}
+
+// adminRestrictedResolver only calls the resolver if the user is an administrator of their organisation
+func adminRestrictedResolver(fn userAuthenticatedResolver) graphql.FieldResolveFn {
+	return userRestrictedResolver(func(p graphql.ResolveParams, u auth.User) (interface{}, error) {
+		if !u.IsAdmin() {
+			return nil, errors.New("Only organisation admins can perform this action")
+		}
+		return fn(p, u)
+	})
+}
@@ -10,25 +10,55 @@ import (
 type meetingTypes struct {
 	answerInterface   *graphql.Interface
 	intAnswer         *graphql.Object
+	stringAnswer      *graphql.Object
+	choiceAnswer      *graphql.Object
+	choicesAnswer     *graphql.Object
+	floatAnswer       *graphql.Object
+	answerInput       *graphql.InputObject
 	categoryAggregate *graphql.Object
 	aggregates        *graphql.Object
 	meetingType       *graphql.Object
+	meetingStatusEnum *graphql.Enum
+	meetingSortEnum   *graphql.Enum
+	meetingConnection *graphql.Object
 }
 
 type organisationTypes struct {
-	organisationType *graphql.Object
+	organisationType     *graphql.Object
+	profileFieldType     *graphql.Object
+	profileFieldTypeEnum *graphql.Enum
 }
 
 type outcomeSetTypes struct {
-	questionInterface *graphql.Interface
-	likertScale       *graphql.Object
-	outcomeSetType    *graphql.Object
-	aggregationEnum   *graphql.Enum
-	categoryType      *graphql.Object
+	questionInterface       *graphql.Interface
+	likertScale             *graphql.Object
+	freeTextQuestion        *graphql.Object
+	singleChoiceQuestion    *graphql.Object
+	multipleChoiceQuestion  *graphql.Object
+	numericQuestion         *graphql.Object
+	choiceType              *graphql.Object
+	choiceInput             *graphql.InputObject
+	outcomeSetType          *graphql.Object
+	aggregationEnum         *graphql.Enum
+	changeThresholdTypeEnum *graphql.Enum
+	categoryType            *graphql.Object
+}
+
+type beneficiaryTypes struct {
+	beneficiaryType   *graphql.Object
+	beneficiaryChange *graphql.Object
+	erasure           *graphql.Object
+	profileValueInput *graphql.InputObject
 }
 
 type reportTypes struct {
-	JOCType *graphql.Object
+	JOCType         *graphql.Object
+	BeneficiaryType *graphql.Object
+	TrendType       *graphql.Object
+	VisitType       *graphql.Object
+	AttritionType   *graphql.Object
+	BaselineEnum    *graphql.Enum
+	TrendPeriodEnum *graphql.Enum
 }
 
 type v1 struct {
@@ -42,8 +72,9 @@ func NewV1(db data.Base) (http.Handler, error) {
 	orgTypes := v.initOrgTypes()
 	osTypes := v.initOutcomeSetTypes(orgTypes)
 	meetTypes := v.initMeetingTypes(orgTypes, osTypes)
-	repTypes := v.initRepTypes()
-	schema, err := v.getSchema(orgTypes, osTypes, meetTypes, repTypes)
+	benTypes := v.initBeneficiaryTypes(meetTypes)
+	repTypes := v.initRepTypes(meetTypes)
+	schema, err := v.getSchema(orgTypes, osTypes, meetTypes, benTypes, repTypes)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,44 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrorMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockUser := mock.NewMockUser(mockCtrl)
+	mockDB := mock.NewMockBase(mockCtrl)
+	mockDB.EXPECT().NewAnswer("M1", gomock.Any(), mockUser).Return(impact.Meeting{}, impact.ValidationErrors{{
+		Field:   "answer",
+		Message: "Must be between 1 and 5",
+	}})
+
+	out := postGraphQL(t, mockDB, mockUser, `mutation {
+		AddLikertAnswer(meetingID: "M1", questionID: "Q1", value: 7) { id }
+	}`)
+	if !assert.Len(t, out.Errors, 1) {
+		return
+	}
+	assert.Equal(t, `Validation failed: [{"field":"answer","message":"Must be between 1 and 5"}]`, out.Errors[0].Message)
+}
+
+func TestValidationErrorMessageInput(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockUser := mock.NewMockUser(mockCtrl)
+	mockDB := mock.NewMockBase(mockCtrl)
+
+	out := postGraphQL(t, mockDB, mockUser, `mutation {
+		EditAnswer(meetingID: "M1", answer: {questionID: "Q1", intValue: 1, stringValue: "one"}) { id }
+	}`)
+	if !assert.Len(t, out.Errors, 1) {
+		return
+	}
+	assert.Equal(t, `Validation failed: [{"field":"answer","message":"Exactly one value must be provided"}]`, out.Errors[0].Message)
+}
@@ -14,6 +14,11 @@ func newContextWithUser(ctx context.Context, userObj User) context.Context {
 	return context.WithValue(ctx, userIndex, userObj)
 }
 
+// NewContext returns a copy of ctx holding the user, as Middleware does for authenticated requests
+func NewContext(ctx context.Context, userObj User) context.Context {
+	return newContextWithUser(ctx, userObj)
+}
+
 func newContextWithAuthError(ctx context.Context, err error) context.Context {
 	return context.WithValue(ctx, authErrorIndex, err)
 }
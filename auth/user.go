@@ -21,6 +21,7 @@ TzskiF5Zzsgc86b2P6kQ1H2ryKp1jNDjkBCpr3F7KfNek/ADrSZVpxFSg4cve7X3
 type User interface {
 	Organisation() (string, error)
 	UserID() string
+	IsAdmin() bool
 }
 
 type auth0User struct {
@@ -69,3 +70,10 @@ func (u *auth0User) Organisation() (string, error) {
 func (u *auth0User) UserID() string {
 	return u.Subject
 }
+
+// IsAdmin returns true if the user is an administrator of their organisation.
+// Admins are identified by an admin flag within the user's app_metadata.
+func (u *auth0User) IsAdmin() bool {
+	admin, ok := u.AppMetadata["admin"].(bool)
+	return ok && admin
+}
@@ -0,0 +1,58 @@
+package server
+
+import "time"
+
+// Beneficiary is a person whose journey of change is tracked through meetings.
+// Beneficiary IDs are chosen by the organisation and are unique within it.
+// The meeting statistics are derived from the beneficiary's meetings, excluding deleted meetings.
+type Beneficiary struct {
+	ID             string     `json:"id"`
+	OrganisationID string     `json:"organisationID" bson:"organisationID"`
+	Created        time.Time  `json:"created"`
+	FirstMeeting   *time.Time `json:"firstMeeting" bson:"firstMeeting"`
+	LastMeeting    *time.Time `json:"lastMeeting" bson:"lastMeeting"`
+	MeetingCount   int        `json:"meetingCount" bson:"meetingCount"`
+	Archived       bool       `json:"archived"`
+	Tags           []string   `json:"tags"`
+	// Profile holds the beneficiary's values for the organisation's profile fields, keyed by field ID.
+	// Enum and text values are strings, numbers are float64s and dates are time.Times.
+	Profile map[string]interface{} `json:"profile" bson:"profile,omitempty"`
+}
+
+// HasTag returns true if the beneficiary has been given the tag
+func (b Beneficiary) HasTag(tag string) bool {
+	for _, t := range b.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// BeneficiaryChangeType describes how a beneficiary's ID was changed
+type BeneficiaryChangeType string
+
+const (
+	RENAME BeneficiaryChangeType = "rename"
+	MERGE  BeneficiaryChangeType = "merge"
+)
+
+// BeneficiaryChange is an audit record of a beneficiary ID being changed on the organisation's meetings.
+// A rename moves the meetings of From to the new ID To. A merge moves the meetings of From to the existing beneficiary To.
+// The changed meetings and From's beneficiary record are stored so the change can be reversed.
+// Each moved meeting is marked with the ID of the change which moved it.
+type BeneficiaryChange struct {
+	ID              string                `json:"id" bson:"_id"`
+	OrganisationID  string                `json:"organisationID" bson:"organisationID"`
+	Type            BeneficiaryChangeType `json:"type"`
+	From            string                `json:"from"`
+	To              string                `json:"to"`
+	MeetingIDs      []string              `json:"meetingIDs" bson:"meetingIDs"`
+	FromBeneficiary *Beneficiary          `json:"fromBeneficiary" bson:"fromBeneficiary"`
+	User            string                `json:"user"`
+	Created         time.Time             `json:"created"`
+	Reversed        *time.Time            `json:"reversed"`
+	ReversedBy      string                `json:"reversedBy" bson:"reversedBy"`
+	// Pending is set while the change is being made. Pending changes are not listed and cannot be reversed.
+	Pending bool `json:"-" bson:"pending,omitempty"`
+}
@@ -19,6 +19,13 @@ type configNetwork struct {
 	Port int `envconfig:"PORT" default:"80"`
 }
 
+type configErasure struct {
+	// Key is the secret, of at least 32 characters, used to hash the IDs of erased beneficiaries.
+	// Changing the key prevents erasures made with the previous key from being found by beneficiary ID.
+	// Beneficiaries cannot be erased if the key is not set.
+	Key string `envconfig:"ERASURE_HMAC_KEY"`
+}
+
 type configErrorTracking struct {
 	DSN string `envconfig:"SENTRY_DSN" default:""`
 }
@@ -27,6 +34,7 @@ type config struct {
 	Mongo   configMongo
 	Network configNetwork
 	Sentry  configErrorTracking
+	Erasure configErasure
 }
 
 func mustGetConfiguration() *config {
@@ -17,7 +17,7 @@ func main() {
 
 	mustConfigureLogger(c)
 
-	db, err := mongo.New(c.Mongo.URL, c.Mongo.Port, c.Mongo.Database, c.Mongo.User, c.Mongo.Password)
+	db, err := mongo.New(c.Mongo.URL, c.Mongo.Port, c.Mongo.Database, c.Mongo.User, c.Mongo.Password, c.Erasure.Key)
 	if err != nil {
 		log.Fatal(err, nil)
 	}
@@ -32,6 +32,7 @@ func main() {
 		AllowedHeaders:   []string{"Authorization", "Content-Type"},
 	})
 	http.Handle("/v1/graphql", cors.Handler(auth.Middleware(v1Handler)))
+	http.Handle("/v1/export/", cors.Handler(auth.Middleware(api.NewExport(db))))
 
 	http.ListenAndServe(":"+strconv.Itoa(c.Network.Port), nil)
 }
@@ -0,0 +1,76 @@
+// Command repair runs one off data repair tasks against the mongo database.
+// It is configured using the same MONGO_* environmental variables as the server.
+//
+// Usage:
+//
+//	repair collapse-answers
+//	repair backfill-beneficiaries
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/impactasaurus/server/data/mongo"
+	"github.com/impactasaurus/server/log"
+	"github.com/kelseyhightower/envconfig"
+)
+
+type configMongo struct {
+	User     string `envconfig:"MONGO_USER"`
+	Password string `envconfig:"MONGO_PASS"`
+	URL      string `envconfig:"MONGO_URL" required:"true"`
+	Port     int    `envconfig:"MONGO_PORT" required:"true"`
+	Database string `envconfig:"MONGO_DB" required:"true"`
+}
+
+type task func(m mongo.Maintenance) (int, error)
+
+var tasks = map[string]task{
+	"collapse-answers": func(m mongo.Maintenance) (int, error) {
+		return m.CollapseDuplicateAnswers()
+	},
+	"backfill-beneficiaries": func(m mongo.Maintenance) (int, error) {
+		return m.BackfillBeneficiaries()
+	},
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		usage()
+	}
+	t, ok := tasks[os.Args[1]]
+	if !ok {
+		usage()
+	}
+
+	c := configMongo{}
+	envconfig.MustProcess("", &c)
+
+	m, err := mongo.NewMaintenance(c.URL, c.Port, c.Database, c.User, c.Password)
+	if err != nil {
+		log.Fatal(err, nil)
+	}
+
+	altered, err := t(m)
+	if err != nil {
+		log.Fatal(err, map[string]string{
+			"task":    os.Args[1],
+			"altered": strconv.Itoa(altered),
+		})
+	}
+	log.Info("Repair complete", map[string]string{
+		"task":    os.Args[1],
+		"altered": strconv.Itoa(altered),
+	})
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: repair <task>")
+	fmt.Fprintln(os.Stderr, "Tasks:")
+	for name := range tasks {
+		fmt.Fprintf(os.Stderr, "  %s\n", name)
+	}
+	os.Exit(2)
+}
@@ -21,6 +21,12 @@ func (nf *notFound) Error() string {
 	return fmt.Sprintf("%s not found", nf.thing)
 }
 
+// IsNotFound returns true if the error was created by NewNotFoundError
+func IsNotFound(err error) bool {
+	_, ok := err.(*notFound)
+	return ok
+}
+
 type Base interface {
 	NewOutcomeSet(name, description string, u auth.User) (impact.OutcomeSet, error)
 	EditOutcomeSet(id, name, description string, u auth.User) (impact.OutcomeSet, error)
@@ -33,20 +39,59 @@ type Base interface {
 	DeleteQuestion(outcomeSetID, questionID string, u auth.User) error
 	EditQuestion(outcomeSetID, questionID, question, description string, questionType impact.QuestionType, options map[string]interface{}, u auth.User) (impact.Question, error)
 	MoveQuestion(outcomeSetID, questionID string, newIndex uint, u auth.User) error
+	NewChoiceQuestion(outcomeSetID, question, description string, questionType impact.QuestionType, choices []impact.Choice, u auth.User) (impact.Question, error)
+	EditChoiceQuestion(outcomeSetID, questionID, question, description string, choices []impact.Choice, u auth.User) (impact.Question, error)
 
 	GetCategory(outcomeSetID, categoryID string, u auth.User) (impact.Category, error)
 	NewCategory(outcomeSetID, name, description string, aggregation impact.Aggregation, u auth.User) (impact.Category, error)
 	DeleteCategory(outcomeSetID, categoryID string, u auth.User) error
 	EditCategory(outcomeSetID, categoryID string, name, description string, aggregation impact.Aggregation, u auth.User) (impact.Category, error)
+	// SetCategoryChangeThreshold sets the threshold used to decide whether beneficiaries improved or declined in the category.
+	// A nil threshold removes the category's threshold, so any change is counted.
+	SetCategoryChangeThreshold(outcomeSetID, categoryID string, threshold *impact.ChangeThreshold, u auth.User) (impact.Category, error)
 	SetCategory(outcomeSetID, questionID, categoryID string, u auth.User) (impact.Question, error)
 	RemoveCategory(outcomeSetID, questionID string, u auth.User) (impact.Question, error)
 
 	GetOrganisation(id string, u auth.User) (impact.Organisation, error)
+	SetOrganisationTimeZone(timeZone string, u auth.User) (impact.Organisation, error)
+	NewProfileField(name, description string, fieldType impact.ProfileFieldType, options []string, u auth.User) (impact.ProfileField, error)
+	EditProfileField(id, name, description string, options []string, u auth.User) (impact.ProfileField, error)
+	DeleteProfileField(id string, u auth.User) error
+
+	GetBeneficiary(id string, u auth.User) (impact.Beneficiary, error)
+	GetBeneficiaries(includeArchived bool, u auth.User) ([]impact.Beneficiary, error)
+	SetBeneficiaryArchived(id string, archived bool, u auth.User) (impact.Beneficiary, error)
+	AddBeneficiaryTags(id string, tags []string, u auth.User) (impact.Beneficiary, error)
+	RemoveBeneficiaryTags(id string, tags []string, u auth.User) (impact.Beneficiary, error)
+	GetBeneficiaryTags(u auth.User) ([]string, error)
+	SetBeneficiaryProfileValues(beneficiaryID string, values map[string]interface{}, u auth.User) (impact.Beneficiary, error)
+	ClearBeneficiaryProfileValue(beneficiaryID, fieldID string, u auth.User) (impact.Beneficiary, error)
+	RenameBeneficiary(id, newID string, u auth.User) (impact.BeneficiaryChange, error)
+	MergeBeneficiaries(sourceID, targetID string, u auth.User) (impact.BeneficiaryChange, error)
+	ReverseBeneficiaryChange(id string, u auth.User) (impact.BeneficiaryChange, error)
+	GetBeneficiaryChanges(beneficiaryID string, u auth.User) ([]impact.BeneficiaryChange, error)
+	EraseBeneficiary(beneficiaryID string, u auth.User) (impact.Erasure, error)
+	GetErasures(beneficiaryID string, u auth.User) ([]impact.Erasure, error)
 
 	GetMeeting(id string, u auth.User) (impact.Meeting, error)
 	GetMeetingsForBeneficiary(beneficiary string, u auth.User) ([]impact.Meeting, error)
+	GetMeetings(filter MeetingFilter, page MeetingPageRequest, u auth.User) (MeetingPage, error)
 	GetOSMeetingsInTimeRange(start, end time.Time, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
 	GetOSMeetingsForBeneficiary(beneficiary string, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
+	// GetOSMeetingsForBeneficiaries returns the outcome set's meetings for all of the beneficiaries in a single query
+	GetOSMeetingsForBeneficiaries(beneficiaries []string, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
 	NewMeeting(beneficiaryID, outcomeSetID string, conducted time.Time, u auth.User) (impact.Meeting, error)
+	NewMeetingWithAnswers(beneficiaryID, outcomeSetID string, conducted time.Time, answers []impact.Answer, u auth.User) (impact.Meeting, error)
+	CompleteMeeting(id string, u auth.User) (impact.Meeting, error)
+	AbandonMeeting(id string, u auth.User) (impact.Meeting, error)
+	DeleteMeeting(id string, u auth.User) error
+	RestoreMeeting(id string, u auth.User) (impact.Meeting, error)
+	// SetMeetingBaseline marks or unmarks the meeting as the beneficiary's baseline for the meeting's outcome set.
+	// Marking a meeting as the baseline unmarks any other baseline meeting the beneficiary has for the outcome set.
+	SetMeetingBaseline(id string, baseline bool, u auth.User) (impact.Meeting, error)
 	NewAnswer(meetingID string, answer impact.Answer, u auth.User) (impact.Meeting, error)
+	// EditAnswer replaces the answer to a question, moving completed and abandoned meetings back to in progress
+	EditAnswer(meetingID string, answer impact.Answer, u auth.User) (impact.Meeting, error)
+	// DeleteAnswer removes the answer to a question, moving completed and abandoned meetings back to in progress
+	DeleteAnswer(meetingID, questionID string, u auth.User) (impact.Meeting, error)
 }
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"time"
+
+	impact "github.com/impactasaurus/server"
+)
+
+// MeetingSortField is the field meetings are ordered by when paging through them
+type MeetingSortField string
+
+const (
+	SortByConducted MeetingSortField = "conducted"
+	SortByCreated   MeetingSortField = "created"
+)
+
+// MeetingFilter restricts the meetings returned by GetMeetings. Empty fields are not used to filter.
+type MeetingFilter struct {
+	// Beneficiary matches a beneficiary ID exactly, it takes precedence over BeneficiaryPrefix
+	Beneficiary       string
+	OutcomeSetID      string
+	User              string
+	BeneficiaryPrefix string
+	// ConductedAfter and ConductedBefore are inclusive bounds on when the meeting was conducted
+	ConductedAfter  *time.Time
+	ConductedBefore *time.Time
+	IncludeDeleted  bool
+}
+
+// MeetingPageRequest describes which page of meetings should be returned by GetMeetings
+type MeetingPageRequest struct {
+	First     int
+	After     string
+	SortBy    MeetingSortField
+	Ascending bool
+}
+
+// MeetingEdge is a meeting along with the cursor which can be used to request the meetings following it
+type MeetingEdge struct {
+	Cursor  string         `json:"cursor"`
+	Meeting impact.Meeting `json:"node"`
+}
+
+// MeetingPage is a page of meetings returned by GetMeetings
+type MeetingPage struct {
+	Edges       []MeetingEdge `json:"edges"`
+	HasNextPage bool          `json:"hasNextPage"`
+}
+
+// EndCursor returns the cursor of the last meeting in the page, or an empty string if the page is empty
+func (p MeetingPage) EndCursor() string {
+	if len(p.Edges) == 0 {
+		return ""
+	}
+	return p.Edges[len(p.Edges)-1].Cursor
+}
+
+// MaxMeetingPageSize is the largest number of meetings which can be requested in a single page
+const MaxMeetingPageSize = 100
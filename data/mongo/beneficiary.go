@@ -0,0 +1,240 @@
+package mongo
+
+import (
+	"sort"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+	"github.com/impactasaurus/server/log"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func (m *mongo) GetBeneficiary(id string, u auth.User) (impact.Beneficiary, error) {
+	ben := impact.Beneficiary{}
+
+	col, closer := m.getBeneficiaryCollection()
+	defer closer()
+
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return ben, err
+	}
+
+	err = col.Find(bson.M{
+		"id":             id,
+		"organisationID": userOrg,
+	}).One(&ben)
+	if err != nil {
+		if mgo.ErrNotFound == err {
+			return ben, data.NewNotFoundError("Beneficiary")
+		}
+		return ben, err
+	}
+	return ben, nil
+}
+
+func (m *mongo) GetBeneficiaries(includeArchived bool, u auth.User) ([]impact.Beneficiary, error) {
+	col, closer := m.getBeneficiaryCollection()
+	defer closer()
+
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+
+	query := bson.M{
+		"organisationID": userOrg,
+	}
+	if !includeArchived {
+		query["archived"] = bson.M{"$ne": true}
+	}
+
+	results := []impact.Beneficiary{}
+	if err := col.Find(query).Sort("id").All(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (m *mongo) SetBeneficiaryArchived(id string, archived bool, u auth.User) (impact.Beneficiary, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Beneficiary{}, err
+	}
+
+	col, closer := m.getBeneficiaryCollection()
+	defer closer()
+
+	if err := col.Update(bson.M{
+		"id":             id,
+		"organisationID": userOrg,
+	}, bson.M{
+		"$set": bson.M{
+			"archived": archived,
+		},
+	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Beneficiary{}, data.NewNotFoundError("Beneficiary")
+		}
+		return impact.Beneficiary{}, err
+	}
+	return m.GetBeneficiary(id, u)
+}
+
+func (m *mongo) updateBeneficiaryTags(id string, tags []string, update func([]string) bson.M, u auth.User) (impact.Beneficiary, error) {
+	tags, err := impact.CleanTags(tags)
+	if err != nil {
+		return impact.Beneficiary{}, err
+	}
+
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Beneficiary{}, err
+	}
+
+	col, closer := m.getBeneficiaryCollection()
+	defer closer()
+
+	if err := col.Update(bson.M{
+		"id":             id,
+		"organisationID": userOrg,
+	}, update(tags)); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Beneficiary{}, data.NewNotFoundError("Beneficiary")
+		}
+		return impact.Beneficiary{}, err
+	}
+	return m.GetBeneficiary(id, u)
+}
+
+func (m *mongo) AddBeneficiaryTags(id string, tags []string, u auth.User) (impact.Beneficiary, error) {
+	return m.updateBeneficiaryTags(id, tags, func(tags []string) bson.M {
+		return bson.M{
+			"$addToSet": bson.M{
+				"tags": bson.M{"$each": tags},
+			},
+		}
+	}, u)
+}
+
+func (m *mongo) RemoveBeneficiaryTags(id string, tags []string, u auth.User) (impact.Beneficiary, error) {
+	return m.updateBeneficiaryTags(id, tags, func(tags []string) bson.M {
+		return bson.M{
+			"$pullAll": bson.M{
+				"tags": tags,
+			},
+		}
+	}, u)
+}
+
+// GetBeneficiaryTags returns every tag used by the organisation's beneficiaries
+func (m *mongo) GetBeneficiaryTags(u auth.User) ([]string, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+
+	col, closer := m.getBeneficiaryCollection()
+	defer closer()
+
+	tags := []string{}
+	if err := col.Find(bson.M{
+		"organisationID": userOrg,
+	}).Distinct("tags", &tags); err != nil {
+		return nil, err
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+type beneficiaryMeetingStats struct {
+	Count   int       `bson:"count"`
+	First   time.Time `bson:"first"`
+	Last    time.Time `bson:"last"`
+	Created time.Time `bson:"created"`
+}
+
+// refreshBeneficiary recalculates a beneficiary's meeting statistics from their meetings.
+// The beneficiary is created if they do not exist and have at least one meeting.
+func (m *mongo) refreshBeneficiary(orgID, benID string) error {
+	mCol, mCloser := m.getMeetingCollection()
+	defer mCloser()
+
+	stats := []beneficiaryMeetingStats{}
+	if err := mCol.Pipe([]bson.M{{
+		"$match": bson.M{
+			"organisationID": orgID,
+			"beneficiary":    benID,
+			"deleted":        notDeleted,
+		},
+	}, {
+		"$group": bson.M{
+			"_id":     nil,
+			"count":   bson.M{"$sum": 1},
+			"first":   bson.M{"$min": "$conducted"},
+			"last":    bson.M{"$max": "$conducted"},
+			"created": bson.M{"$min": "$created"},
+		},
+	}}).All(&stats); err != nil {
+		return err
+	}
+
+	bCol, bCloser := m.getBeneficiaryCollection()
+	defer bCloser()
+
+	selector := bson.M{
+		"id":             benID,
+		"organisationID": orgID,
+	}
+	if len(stats) == 0 {
+		err := bCol.Update(selector, bson.M{
+			"$set": bson.M{
+				"meetingCount": 0,
+				"firstMeeting": nil,
+				"lastMeeting":  nil,
+			},
+		})
+		if err == mgo.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	s := stats[0]
+	upsert := func() error {
+		_, err := bCol.Upsert(selector, bson.M{
+			"$set": bson.M{
+				"meetingCount": s.Count,
+				"firstMeeting": s.First,
+				"lastMeeting":  s.Last,
+			},
+			"$setOnInsert": bson.M{
+				"created":  s.Created,
+				"archived": false,
+			},
+		})
+		return err
+	}
+	err := upsert()
+	if mgo.IsDup(err) {
+		// a concurrent request created the beneficiary, retrying will update it
+		err = upsert()
+	}
+	return err
+}
+
+// refreshBeneficiaryAfterChange refreshes the beneficiary after one of their meetings has been altered.
+// Failures are logged rather than returned as the meeting change has already been stored,
+// the beneficiary can be corrected by running the backfill-beneficiaries repair task.
+func (m *mongo) refreshBeneficiaryAfterChange(orgID, benID string) {
+	if err := m.refreshBeneficiary(orgID, benID); err != nil {
+		log.Error(err, map[string]string{
+			"message": "Refreshing beneficiary failed",
+			"orgID":   orgID,
+			"ben":     benID,
+		})
+	}
+}
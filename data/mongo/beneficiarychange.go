@@ -0,0 +1,367 @@
+package mongo
+
+import (
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+	"github.com/impactasaurus/server/log"
+	"github.com/satori/go.uuid"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// changedMeetingIDs returns the IDs of the meetings moved by the beneficiary change
+func changedMeetingIDs(col *mgo.Collection, userOrg, changeID string) ([]string, error) {
+	results := []struct {
+		ID string `bson:"_id"`
+	}{}
+	if err := col.Find(bson.M{
+		"organisationID":      userOrg,
+		"beneficiaryChangeID": changeID,
+	}).Select(bson.M{"_id": 1}).All(&results); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
+
+// findBeneficiary returns the beneficiary's record or nil if the beneficiary does not have one
+func findBeneficiary(col *mgo.Collection, userOrg, benID string) (*impact.Beneficiary, error) {
+	ben := impact.Beneficiary{}
+	err := col.Find(bson.M{
+		"id":             benID,
+		"organisationID": userOrg,
+	}).One(&ben)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ben, nil
+}
+
+// changeBeneficiary moves all of the from beneficiary's meetings to the to beneficiary, recording the change so it can be reversed.
+// The audit record is pending until the meetings and beneficiary record have been changed. If any step fails, the change is
+// rolled back. targetField names the argument holding the to beneficiary, it is used in validation errors.
+func (m *mongo) changeBeneficiary(changeType impact.BeneficiaryChangeType, from, to, targetField string, u auth.User) (impact.BeneficiaryChange, error) {
+	if from == to {
+		return impact.BeneficiaryChange{}, impact.ValidationErrors{{Field: targetField, Message: "Must be different to the current beneficiary ID"}}
+	}
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+
+	mCol, mCloser := m.getMeetingCollection()
+	defer mCloser()
+	bCol, bCloser := m.getBeneficiaryCollection()
+	defer bCloser()
+
+	fromMeetings, err := mCol.Find(bson.M{
+		"organisationID": userOrg,
+		"beneficiary":    from,
+	}).Count()
+	if err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+	if fromMeetings == 0 {
+		return impact.BeneficiaryChange{}, data.NewNotFoundError("Beneficiary")
+	}
+	toMeetings, err := mCol.Find(bson.M{
+		"organisationID": userOrg,
+		"beneficiary":    to,
+	}).Count()
+	if err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+	toBen, err := findBeneficiary(bCol, userOrg, to)
+	if err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+	toExists := toMeetings > 0 || toBen != nil
+	if changeType == impact.RENAME && toExists {
+		return impact.BeneficiaryChange{}, impact.ValidationErrors{{Field: targetField, Message: "A beneficiary with this ID already exists, merge the beneficiaries instead"}}
+	}
+	if changeType == impact.MERGE && !toExists {
+		return impact.BeneficiaryChange{}, impact.ValidationErrors{{Field: targetField, Message: "Beneficiary does not exist, rename the beneficiary instead"}}
+	}
+	fromBen, err := findBeneficiary(bCol, userOrg, from)
+	if err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+
+	// the change is recorded as pending before any meeting is moved, so an interrupted change can be audited
+	change := impact.BeneficiaryChange{
+		ID:              uuid.NewV4().String(),
+		OrganisationID:  userOrg,
+		Type:            changeType,
+		From:            from,
+		To:              to,
+		MeetingIDs:      []string{},
+		FromBeneficiary: fromBen,
+		User:            u.UserID(),
+		Created:         time.Now(),
+		Pending:         true,
+	}
+	cCol, cCloser := m.getBeneficiaryChangeCollection()
+	defer cCloser()
+	if err := cCol.Insert(change); err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+
+	// meetings are selected by beneficiary rather than a snapshot of IDs, so meetings added since the checks above are
+	// also moved. Each moved meeting is marked with the change's ID, allowing the moved meetings to be recorded.
+	if _, err := mCol.UpdateAll(bson.M{
+		"organisationID": userOrg,
+		"beneficiary":    from,
+	}, bson.M{
+		"$set": bson.M{
+			"beneficiary":         to,
+			"beneficiaryChangeID": change.ID,
+			"modified":            time.Now(),
+		},
+	}); err != nil {
+		return impact.BeneficiaryChange{}, m.rollbackBeneficiaryChange(change, err)
+	}
+	if change.MeetingIDs, err = changedMeetingIDs(mCol, userOrg, change.ID); err != nil {
+		return impact.BeneficiaryChange{}, m.rollbackBeneficiaryChange(change, err)
+	}
+	if len(change.MeetingIDs) == 0 {
+		// the beneficiary's meetings were moved or erased since they were counted
+		return impact.BeneficiaryChange{}, m.rollbackBeneficiaryChange(change, data.NewNotFoundError("Beneficiary"))
+	}
+
+	selector := bson.M{
+		"id":             from,
+		"organisationID": userOrg,
+	}
+	if changeType == impact.RENAME {
+		err = bCol.Update(selector, bson.M{
+			"$set": bson.M{
+				"id": to,
+			},
+		})
+	} else {
+		err = bCol.Remove(selector)
+	}
+	if err != nil && err != mgo.ErrNotFound {
+		return impact.BeneficiaryChange{}, m.rollbackBeneficiaryChange(change, err)
+	}
+
+	change.Pending = false
+	if err := cCol.UpdateId(change.ID, bson.M{
+		"$set": bson.M{
+			"meetingIDs": change.MeetingIDs,
+		},
+		"$unset": bson.M{
+			"pending": "",
+		},
+	}); err != nil {
+		return impact.BeneficiaryChange{}, m.rollbackBeneficiaryChange(change, err)
+	}
+	m.refreshBeneficiaryAfterChange(userOrg, to)
+	return change, nil
+}
+
+// rollbackBeneficiaryChange moves any meetings moved by the failed change back to the change's original beneficiary,
+// restores the original beneficiary's record and removes the pending audit record. The cause of the failure is returned.
+// If the rollback fails, the audit record is left pending and the failure is logged.
+func (m *mongo) rollbackBeneficiaryChange(change impact.BeneficiaryChange, cause error) error {
+	mCol, mCloser := m.getMeetingCollection()
+	defer mCloser()
+	bCol, bCloser := m.getBeneficiaryCollection()
+	defer bCloser()
+	cCol, cCloser := m.getBeneficiaryChangeCollection()
+	defer cCloser()
+
+	logFailure := func(err error) error {
+		log.Error(err, map[string]string{
+			"changeID": change.ID,
+			"orgID":    change.OrganisationID,
+			"cause":    cause.Error(),
+		})
+		return cause
+	}
+	if _, err := mCol.UpdateAll(bson.M{
+		"organisationID":      change.OrganisationID,
+		"beneficiaryChangeID": change.ID,
+		"beneficiary":         change.To,
+	}, bson.M{
+		"$set": bson.M{
+			"beneficiary": change.From,
+			"modified":    time.Now(),
+		},
+		"$unset": bson.M{
+			"beneficiaryChangeID": "",
+		},
+	}); err != nil {
+		return logFailure(err)
+	}
+	if b := change.FromBeneficiary; b != nil {
+		if _, err := bCol.Upsert(bson.M{
+			"id":             change.From,
+			"organisationID": change.OrganisationID,
+		}, bson.M{
+			"$set": bson.M{
+				"created":  b.Created,
+				"archived": b.Archived,
+				"tags":     b.Tags,
+				"profile":  b.Profile,
+			},
+		}); err != nil {
+			return logFailure(err)
+		}
+	}
+	if change.Type == impact.RENAME {
+		if err := bCol.Remove(bson.M{
+			"id":             change.To,
+			"organisationID": change.OrganisationID,
+		}); err != nil && err != mgo.ErrNotFound {
+			return logFailure(err)
+		}
+	}
+	m.refreshBeneficiaryAfterChange(change.OrganisationID, change.From)
+	m.refreshBeneficiaryAfterChange(change.OrganisationID, change.To)
+	if err := cCol.RemoveId(change.ID); err != nil {
+		return logFailure(err)
+	}
+	return cause
+}
+
+// RenameBeneficiary changes the beneficiary ID of all of the beneficiary's meetings to a new, unused ID.
+// The beneficiary's tags and profile are kept.
+func (m *mongo) RenameBeneficiary(id, newID string, u auth.User) (impact.BeneficiaryChange, error) {
+	return m.changeBeneficiary(impact.RENAME, id, newID, "newID", u)
+}
+
+// MergeBeneficiaries moves all of the source beneficiary's meetings to the target beneficiary.
+// The target's tags and profile are kept and the source's are discarded.
+func (m *mongo) MergeBeneficiaries(sourceID, targetID string, u auth.User) (impact.BeneficiaryChange, error) {
+	return m.changeBeneficiary(impact.MERGE, sourceID, targetID, "targetID", u)
+}
+
+// ReverseBeneficiaryChange moves the meetings altered by a rename or merge back to their original beneficiary and
+// restores the original beneficiary's record. Meetings added to the new beneficiary after the change are not moved.
+func (m *mongo) ReverseBeneficiaryChange(id string, u auth.User) (impact.BeneficiaryChange, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+
+	cCol, cCloser := m.getBeneficiaryChangeCollection()
+	defer cCloser()
+
+	now := time.Now()
+	change := impact.BeneficiaryChange{}
+	selector := bson.M{
+		"_id":            id,
+		"organisationID": userOrg,
+		"pending":        bson.M{"$ne": true},
+	}
+	if _, err := cCol.Find(bson.M{
+		"_id":            id,
+		"organisationID": userOrg,
+		"pending":        bson.M{"$ne": true},
+		"reversed":       nil,
+	}).Apply(mgo.Change{
+		Update: bson.M{
+			"$set": bson.M{
+				"reversed":   now,
+				"reversedBy": u.UserID(),
+			},
+		},
+		ReturnNew: true,
+	}, &change); err != nil {
+		if err != mgo.ErrNotFound {
+			return impact.BeneficiaryChange{}, err
+		}
+		count, err := cCol.Find(selector).Count()
+		if err != nil {
+			return impact.BeneficiaryChange{}, err
+		}
+		if count == 0 {
+			return impact.BeneficiaryChange{}, data.NewNotFoundError("Beneficiary change")
+		}
+		return impact.BeneficiaryChange{}, impact.ValidationErrors{{Field: "changeID", Message: "Change has already been reversed"}}
+	}
+
+	mCol, mCloser := m.getMeetingCollection()
+	defer mCloser()
+	if _, err := mCol.UpdateAll(bson.M{
+		"_id":            bson.M{"$in": change.MeetingIDs},
+		"organisationID": userOrg,
+		"beneficiary":    change.To,
+	}, bson.M{
+		"$set": bson.M{
+			"beneficiary": change.From,
+			"modified":    now,
+		},
+	}); err != nil {
+		return impact.BeneficiaryChange{}, err
+	}
+
+	bCol, bCloser := m.getBeneficiaryCollection()
+	defer bCloser()
+	if b := change.FromBeneficiary; b != nil {
+		if _, err := bCol.Upsert(bson.M{
+			"id":             change.From,
+			"organisationID": userOrg,
+		}, bson.M{
+			"$set": bson.M{
+				"created":  b.Created,
+				"archived": b.Archived,
+				"tags":     b.Tags,
+				"profile":  b.Profile,
+			},
+		}); err != nil {
+			return impact.BeneficiaryChange{}, err
+		}
+	}
+	m.refreshBeneficiaryAfterChange(userOrg, change.From)
+	m.refreshBeneficiaryAfterChange(userOrg, change.To)
+	if change.Type == impact.RENAME {
+		// the renamed beneficiary did not exist before the change, remove it if it has no other meetings
+		if err := bCol.Remove(bson.M{
+			"id":             change.To,
+			"organisationID": userOrg,
+			"meetingCount":   0,
+		}); err != nil && err != mgo.ErrNotFound {
+			return impact.BeneficiaryChange{}, err
+		}
+	}
+	return change, nil
+}
+
+// GetBeneficiaryChanges returns the organisation's beneficiary renames and merges, newest first.
+// If a beneficiary ID is provided, only changes to or from the beneficiary are returned.
+func (m *mongo) GetBeneficiaryChanges(beneficiaryID string, u auth.User) ([]impact.BeneficiaryChange, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+
+	col, closer := m.getBeneficiaryChangeCollection()
+	defer closer()
+
+	query := bson.M{
+		"organisationID": userOrg,
+		"pending":        bson.M{"$ne": true},
+	}
+	if beneficiaryID != "" {
+		query["$or"] = []bson.M{
+			{"from": beneficiaryID},
+			{"to": beneficiaryID},
+		}
+	}
+	results := []impact.BeneficiaryChange{}
+	if err := col.Find(query).Sort("-created").All(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
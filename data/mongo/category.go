@@ -7,6 +7,7 @@ import (
 	"github.com/impactasaurus/server/auth"
 	"github.com/impactasaurus/server/data"
 	uuid "github.com/satori/go.uuid"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -127,3 +128,42 @@ func (m *mongo) EditCategory(outcomeSetID, categoryID string, name, description
 	}
 	return m.GetCategory(outcomeSetID, categoryID, u)
 }
+
+func (m *mongo) SetCategoryChangeThreshold(outcomeSetID, categoryID string, threshold *impact.ChangeThreshold, u auth.User) (impact.Category, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Category{}, err
+	}
+	if threshold != nil {
+		if err := threshold.Validate(); err != nil {
+			return impact.Category{}, err
+		}
+	}
+
+	col, closer := m.getOutcomeCollection()
+	defer closer()
+
+	update := bson.M{
+		"$unset": bson.M{
+			"categories.$.changeThreshold": "",
+		},
+	}
+	if threshold != nil {
+		update = bson.M{
+			"$set": bson.M{
+				"categories.$.changeThreshold": threshold,
+			},
+		}
+	}
+	if err := col.Update(bson.M{
+		"_id":            outcomeSetID,
+		"organisationID": userOrg,
+		"categories.id":  categoryID,
+	}, update); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Category{}, data.NewNotFoundError("Category")
+		}
+		return impact.Category{}, err
+	}
+	return m.GetCategory(outcomeSetID, categoryID, u)
+}
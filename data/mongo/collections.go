@@ -18,3 +18,18 @@ func (m *mongo) getOrganisationCollection() (*mgo.Collection, sessionEnder) {
 	session := m.baseSession.Copy()
 	return session.DB("").C("organisations"), session.Close
 }
+
+func (m *mongo) getBeneficiaryCollection() (*mgo.Collection, sessionEnder) {
+	session := m.baseSession.Copy()
+	return session.DB("").C("beneficiaries"), session.Close
+}
+
+func (m *mongo) getBeneficiaryChangeCollection() (*mgo.Collection, sessionEnder) {
+	session := m.baseSession.Copy()
+	return session.DB("").C("beneficiarychanges"), session.Close
+}
+
+func (m *mongo) getErasureCollection() (*mgo.Collection, sessionEnder) {
+	session := m.baseSession.Copy()
+	return session.DB("").C("erasures"), session.Close
+}
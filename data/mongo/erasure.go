@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"errors"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+	"github.com/satori/go.uuid"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// errErasureDisabled is returned by erasure operations when the server has no erasure key to hash beneficiary IDs with
+var errErasureDisabled = errors.New("Beneficiary erasure has not been enabled on this server")
+
+// EraseBeneficiary permanently deletes all of the beneficiary's meetings, including deleted meetings, their beneficiary record
+// and any renames or merges involving them. A tombstone is recorded before anything is deleted so the erasure can be audited,
+// it is marked as completed once all of the data has been removed.
+func (m *mongo) EraseBeneficiary(beneficiaryID string, u auth.User) (impact.Erasure, error) {
+	if len(m.erasureKey) == 0 {
+		return impact.Erasure{}, errErasureDisabled
+	}
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Erasure{}, err
+	}
+
+	mCol, mCloser := m.getMeetingCollection()
+	defer mCloser()
+	bCol, bCloser := m.getBeneficiaryCollection()
+	defer bCloser()
+	cCol, cCloser := m.getBeneficiaryChangeCollection()
+	defer cCloser()
+	eCol, eCloser := m.getErasureCollection()
+	defer eCloser()
+
+	meetingQuery := bson.M{
+		"organisationID": userOrg,
+		"beneficiary":    beneficiaryID,
+	}
+	benQuery := bson.M{
+		"organisationID": userOrg,
+		"id":             beneficiaryID,
+	}
+	changeQuery := bson.M{
+		"organisationID": userOrg,
+		"$or": []bson.M{
+			{"from": beneficiaryID},
+			{"to": beneficiaryID},
+		},
+	}
+
+	meetings, err := mCol.Find(meetingQuery).Count()
+	if err != nil {
+		return impact.Erasure{}, err
+	}
+	bens, err := bCol.Find(benQuery).Count()
+	if err != nil {
+		return impact.Erasure{}, err
+	}
+	if meetings == 0 && bens == 0 {
+		return impact.Erasure{}, data.NewNotFoundError("Beneficiary")
+	}
+
+	erasure := impact.Erasure{
+		ID:              uuid.NewV4().String(),
+		OrganisationID:  userOrg,
+		BeneficiaryHash: impact.HashBeneficiaryID(m.erasureKey, userOrg, beneficiaryID),
+		User:            u.UserID(),
+		Created:         time.Now(),
+	}
+	if err := eCol.Insert(erasure); err != nil {
+		return impact.Erasure{}, err
+	}
+
+	meetingInfo, err := mCol.RemoveAll(meetingQuery)
+	if err != nil {
+		return impact.Erasure{}, err
+	}
+	if err := bCol.Remove(benQuery); err != nil && err != mgo.ErrNotFound {
+		return impact.Erasure{}, err
+	}
+	changeInfo, err := cCol.RemoveAll(changeQuery)
+	if err != nil {
+		return impact.Erasure{}, err
+	}
+
+	completed := time.Now()
+	erasure.MeetingsErased = meetingInfo.Removed
+	erasure.ChangesErased = changeInfo.Removed
+	erasure.Completed = &completed
+	if err := eCol.UpdateId(erasure.ID, bson.M{
+		"$set": bson.M{
+			"meetingsErased": erasure.MeetingsErased,
+			"changesErased":  erasure.ChangesErased,
+			"completed":      completed,
+		},
+	}); err != nil {
+		return impact.Erasure{}, err
+	}
+	return erasure, nil
+}
+
+// GetErasures returns the organisation's erasure tombstones, newest first.
+// If a beneficiary ID is provided, only erasures of the beneficiary are returned, this requires erasure to be enabled.
+func (m *mongo) GetErasures(beneficiaryID string, u auth.User) ([]impact.Erasure, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+
+	col, closer := m.getErasureCollection()
+	defer closer()
+
+	query := bson.M{
+		"organisationID": userOrg,
+	}
+	if beneficiaryID != "" {
+		if len(m.erasureKey) == 0 {
+			return nil, errErasureDisabled
+		}
+		query["beneficiaryHMAC"] = impact.HashBeneficiaryID(m.erasureKey, userOrg, beneficiaryID)
+	}
+	results := []impact.Erasure{}
+	if err := col.Find(query).Sort("-created").All(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
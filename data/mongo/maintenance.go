@@ -0,0 +1,111 @@
+package mongo
+
+import (
+	"strconv"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/log"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Maintenance provides one off data repair operations. These operate across all organisations.
+type Maintenance interface {
+	// CollapseDuplicateAnswers removes all but the latest answer to each question within each meeting.
+	// Meetings modified while the repair runs are skipped and logged, the repair can be run again to collapse them.
+	// The number of meetings altered is returned.
+	CollapseDuplicateAnswers() (int, error)
+	// BackfillBeneficiaries creates or refreshes a beneficiary for every beneficiary referenced by a meeting.
+	// The number of beneficiaries refreshed is returned.
+	BackfillBeneficiaries() (int, error)
+}
+
+// NewMaintenance connects to the mongo database for running maintenance operations
+func NewMaintenance(hostname string, port int, database, user, password string) (Maintenance, error) {
+	return dial(hostname, port, database, user, password)
+}
+
+func (m *mongo) CollapseDuplicateAnswers() (int, error) {
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	altered := 0
+	iter := col.Find(bson.M{
+		"answers.1": bson.M{"$exists": true},
+	}).Iter()
+	for {
+		meeting := impact.Meeting{}
+		if !iter.Next(&meeting) {
+			break
+		}
+		removed := meeting.RemoveDuplicateAnswers()
+		if removed == 0 {
+			continue
+		}
+		err := col.Update(bson.M{
+			"_id":      meeting.ID,
+			"modified": meeting.Modified,
+		}, bson.M{
+			"$set": bson.M{
+				"answers": meeting.Answers,
+			},
+		})
+		if err == mgo.ErrNotFound {
+			// the meeting changed since it was read, its edit will have collapsed the duplicates if it replaced an answer
+			log.Info("Skipped collapsing duplicate answers of a meeting modified during the repair", map[string]string{
+				"meetingID": meeting.ID,
+				"orgID":     meeting.OrganisationID,
+			})
+			continue
+		}
+		if err != nil {
+			iter.Close()
+			return altered, err
+		}
+		log.Info("Collapsed duplicate answers", map[string]string{
+			"meetingID": meeting.ID,
+			"orgID":     meeting.OrganisationID,
+			"removed":   strconv.Itoa(removed),
+		})
+		altered++
+	}
+	return altered, iter.Close()
+}
+
+type beneficiaryKey struct {
+	OrganisationID string `bson:"organisationID"`
+	Beneficiary    string `bson:"beneficiary"`
+}
+
+func (m *mongo) BackfillBeneficiaries() (int, error) {
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	refreshed := 0
+	iter := col.Pipe([]bson.M{{
+		"$match": bson.M{
+			"deleted": notDeleted,
+		},
+	}, {
+		"$group": bson.M{
+			"_id": bson.M{
+				"organisationID": "$organisationID",
+				"beneficiary":    "$beneficiary",
+			},
+		},
+	}}).AllowDiskUse().Iter()
+	for {
+		group := struct {
+			Key beneficiaryKey `bson:"_id"`
+		}{}
+		if !iter.Next(&group) {
+			break
+		}
+		if err := m.refreshBeneficiary(group.Key.OrganisationID, group.Key.Beneficiary); err != nil {
+			iter.Close()
+			return refreshed, err
+		}
+		refreshed++
+	}
+	return refreshed, iter.Close()
+}
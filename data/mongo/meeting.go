@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"errors"
 	impact "github.com/impactasaurus/server"
 	"github.com/impactasaurus/server/auth"
 	"github.com/impactasaurus/server/data"
@@ -10,6 +11,10 @@ import (
 	"time"
 )
 
+// notDeleted matches meetings which have not been soft deleted.
+// Meetings created before soft deletion was introduced do not have a deleted field.
+var notDeleted = bson.M{"$ne": true}
+
 func (m *mongo) GetMeeting(id string, u auth.User) (impact.Meeting, error) {
 	meeting := impact.Meeting{}
 
@@ -24,6 +29,7 @@ func (m *mongo) GetMeeting(id string, u auth.User) (impact.Meeting, error) {
 	err = col.Find(bson.M{
 		"_id":            id,
 		"organisationID": userOrg,
+		"deleted":        notDeleted,
 	}).One(&meeting)
 	if err != nil {
 		if mgo.ErrNotFound == err {
@@ -53,6 +59,7 @@ func (m *mongo) GetMeetingsForBeneficiary(beneficiary string, u auth.User) ([]im
 		err := col.Find(bson.M{
 			"beneficiary":    beneficiary,
 			"organisationID": userOrg,
+			"deleted":        notDeleted,
 		}).All(&results)
 		return results, err
 	}, u)
@@ -64,6 +71,22 @@ func (m *mongo) GetOSMeetingsForBeneficiary(beneficiary string, outcomeSetID str
 		err := col.Find(bson.M{
 			"beneficiary":    beneficiary,
 			"organisationID": userOrg,
+			"deleted":        notDeleted,
+			"outcomeSetID":   outcomeSetID,
+		}).All(&results)
+		return results, err
+	}, u)
+}
+
+func (m *mongo) GetOSMeetingsForBeneficiaries(beneficiaries []string, outcomeSetID string, u auth.User) ([]impact.Meeting, error) {
+	return m.getMeetings(func(col *mgo.Collection, userOrg string) ([]impact.Meeting, error) {
+		results := []impact.Meeting{}
+		err := col.Find(bson.M{
+			"beneficiary": bson.M{
+				"$in": beneficiaries,
+			},
+			"organisationID": userOrg,
+			"deleted":        notDeleted,
 			"outcomeSetID":   outcomeSetID,
 		}).All(&results)
 		return results, err
@@ -75,6 +98,7 @@ func (m *mongo) GetOSMeetingsInTimeRange(start, end time.Time, outcomeSetID stri
 		results := []impact.Meeting{}
 		err := col.Find(bson.M{
 			"organisationID": userOrg,
+			"deleted":        notDeleted,
 			"outcomeSetID":   outcomeSetID,
 			"conducted": bson.M{
 				"$gte": start,
@@ -86,6 +110,24 @@ func (m *mongo) GetOSMeetingsInTimeRange(start, end time.Time, outcomeSetID stri
 }
 
 func (m *mongo) NewMeeting(beneficiaryID, outcomeSetID string, conducted time.Time, u auth.User) (impact.Meeting, error) {
+	return m.insertMeeting(beneficiaryID, outcomeSetID, conducted, []impact.Answer{}, u)
+}
+
+// NewMeetingWithAnswers creates a meeting along with all of its answers.
+// The answers are validated against the outcome set before anything is stored and the meeting is written
+// as a single document, so either the meeting and all of its answers are saved or nothing is.
+func (m *mongo) NewMeetingWithAnswers(beneficiaryID, outcomeSetID string, conducted time.Time, answers []impact.Answer, u auth.User) (impact.Meeting, error) {
+	os, err := m.GetOutcomeSet(outcomeSetID, u)
+	if err != nil {
+		return impact.Meeting{}, err
+	}
+	if err := os.ValidateAnswers(answers); err != nil {
+		return impact.Meeting{}, err
+	}
+	return m.insertMeeting(beneficiaryID, outcomeSetID, conducted, answers, u)
+}
+
+func (m *mongo) insertMeeting(beneficiaryID, outcomeSetID string, conducted time.Time, answers []impact.Answer, u auth.User) (impact.Meeting, error) {
 	userOrg, err := u.Organisation()
 	if err != nil {
 		return impact.Meeting{}, err
@@ -100,6 +142,8 @@ func (m *mongo) NewMeeting(beneficiaryID, outcomeSetID string, conducted time.Ti
 		OutcomeSetID:   outcomeSetID,
 		Beneficiary:    beneficiaryID,
 		Conducted:      conducted,
+		Answers:        answers,
+		Status:         impact.INPROGRESS,
 		Created:        time.Now(),
 		Modified:       time.Now(),
 		User:           u.UserID(),
@@ -108,28 +152,321 @@ func (m *mongo) NewMeeting(beneficiaryID, outcomeSetID string, conducted time.Ti
 	if err := col.Insert(meeting); err != nil {
 		return impact.Meeting{}, err
 	}
+	m.refreshBeneficiaryAfterChange(userOrg, beneficiaryID)
 	return meeting, nil
 }
 
+// validateAnswer ensures the answer is appropriate for the meeting's outcome set
+func (m *mongo) validateAnswer(meetingID string, answer impact.Answer, u auth.User) error {
+	meeting, err := m.GetMeeting(meetingID, u)
+	if err != nil {
+		return err
+	}
+	os, err := m.GetOutcomeSet(meeting.OutcomeSetID, u)
+	if err != nil {
+		return err
+	}
+	return os.ValidateAnswer(answer)
+}
+
+// NewAnswer adds an answer to the meeting. If the question has already been answered, the existing answer is replaced.
 func (m *mongo) NewAnswer(meetingID string, answer impact.Answer, u auth.User) (impact.Meeting, error) {
 	userOrg, err := u.Organisation()
 	if err != nil {
 		return impact.Meeting{}, err
 	}
 
+	if err := m.validateAnswer(meetingID, answer, u); err != nil {
+		return impact.Meeting{}, err
+	}
+
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	err = replaceAnswer(col, userOrg, meetingID, answer, false)
+	if err == mgo.ErrNotFound {
+		err = col.Update(bson.M{
+			"_id":                meetingID,
+			"organisationID":     userOrg,
+			"deleted":            notDeleted,
+			"answers.questionID": bson.M{"$ne": answer.QuestionID},
+		}, bson.M{
+			"$push": bson.M{
+				"answers": answer,
+			},
+			"$set": bson.M{
+				"modified": time.Now(),
+			},
+		})
+	}
+	if err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Meeting{}, data.NewNotFoundError("Meeting")
+		}
+		return impact.Meeting{}, err
+	}
+
+	return m.GetMeeting(meetingID, u)
+}
+
+// replaceAnswerAttempts limits how many times replaceAnswer retries when the meeting is modified concurrently
+const replaceAnswerAttempts = 3
+
+// answersReplacement returns the update replacing the meeting's answers.
+// If reopen is true, the meeting is moved back to in progress so it must be completed again with its changed answers.
+func answersReplacement(answers []impact.Answer, reopen bool) bson.M {
+	set := bson.M{
+		"answers":  answers,
+		"modified": time.Now(),
+	}
+	if reopen {
+		set["status"] = impact.INPROGRESS
+	}
+	return bson.M{
+		"$set": set,
+	}
+}
+
+// answerDeletion returns the update removing the answer to the question.
+// The meeting is moved back to in progress, as it may no longer answer every required question.
+func answerDeletion(questionID string) bson.M {
+	return bson.M{
+		"$pull": bson.M{
+			"answers": bson.M{
+				"questionID": questionID,
+			},
+		},
+		"$set": bson.M{
+			"status":   impact.INPROGRESS,
+			"modified": time.Now(),
+		},
+	}
+}
+
+// replaceAnswer replaces the existing answer to the question, mgo.ErrNotFound is returned if the question has not been answered.
+// Duplicate answers are collapsed before the replacement, so it is always the latest answer to the question which is replaced.
+// The meeting's modified time guards the update against concurrent edits, the replacement is retried if the meeting changes.
+// If reopen is true, the meeting is moved back to in progress.
+func replaceAnswer(col *mgo.Collection, userOrg, meetingID string, answer impact.Answer, reopen bool) error {
+	for attempt := 1; ; attempt++ {
+		meeting := impact.Meeting{}
+		if err := col.Find(bson.M{
+			"_id":                meetingID,
+			"organisationID":     userOrg,
+			"deleted":            notDeleted,
+			"answers.questionID": answer.QuestionID,
+		}).One(&meeting); err != nil {
+			return err
+		}
+		meeting.RemoveDuplicateAnswers()
+		for i, a := range meeting.Answers {
+			if a.QuestionID == answer.QuestionID {
+				meeting.Answers[i] = answer
+			}
+		}
+		err := col.Update(bson.M{
+			"_id":      meetingID,
+			"modified": meeting.Modified,
+		}, answersReplacement(meeting.Answers, reopen))
+		if err != mgo.ErrNotFound {
+			return err
+		}
+		if attempt == replaceAnswerAttempts {
+			return errors.New("The meeting is being edited elsewhere, please try again")
+		}
+	}
+}
+
+// EditAnswer replaces the answer to the question. Completed and abandoned meetings are moved back to in progress,
+// so the edited meeting must be completed again before completed only reports include it.
+func (m *mongo) EditAnswer(meetingID string, answer impact.Answer, u auth.User) (impact.Meeting, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Meeting{}, err
+	}
+
+	if err := m.validateAnswer(meetingID, answer, u); err != nil {
+		return impact.Meeting{}, err
+	}
+
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	if err := replaceAnswer(col, userOrg, meetingID, answer, true); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Meeting{}, data.NewNotFoundError("Answer")
+		}
+		return impact.Meeting{}, err
+	}
+
+	return m.GetMeeting(meetingID, u)
+}
+
+// DeleteAnswer removes the answer to the question. Completed and abandoned meetings are moved back to in progress,
+// so the meeting must be completed again, checking it still answers every required question.
+func (m *mongo) DeleteAnswer(meetingID, questionID string, u auth.User) (impact.Meeting, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Meeting{}, err
+	}
+
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	if err := col.Update(bson.M{
+		"_id":                meetingID,
+		"organisationID":     userOrg,
+		"deleted":            notDeleted,
+		"answers.questionID": questionID,
+	}, answerDeletion(questionID)); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Meeting{}, data.NewNotFoundError("Answer")
+		}
+		return impact.Meeting{}, err
+	}
+
+	return m.GetMeeting(meetingID, u)
+}
+
+func (m *mongo) setMeetingStatus(id string, status impact.MeetingStatus, u auth.User) error {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return err
+	}
+
 	col, closer := m.getMeetingCollection()
 	defer closer()
 
 	if err := col.Update(bson.M{
-		"_id":            meetingID,
+		"_id":            id,
 		"organisationID": userOrg,
+		"deleted":        notDeleted,
 	}, bson.M{
-		"$push": bson.M{
-			"answers": answer,
+		"$set": bson.M{
+			"status":   status,
+			"modified": time.Now(),
 		},
 	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return data.NewNotFoundError("Meeting")
+		}
+		return err
+	}
+	return nil
+}
+
+// CompleteMeeting marks the meeting as complete. All active questions of the outcome set must have been answered.
+func (m *mongo) CompleteMeeting(id string, u auth.User) (impact.Meeting, error) {
+	meeting, err := m.GetMeeting(id, u)
+	if err != nil {
+		return impact.Meeting{}, err
+	}
+	os, err := m.GetOutcomeSet(meeting.OutcomeSetID, u)
+	if err != nil {
 		return impact.Meeting{}, err
 	}
+	if err := os.ValidateComplete(meeting); err != nil {
+		return impact.Meeting{}, err
+	}
+	if err := m.setMeetingStatus(id, impact.COMPLETE, u); err != nil {
+		return impact.Meeting{}, err
+	}
+	return m.GetMeeting(id, u)
+}
 
-	return m.GetMeeting(meetingID, u)
+func (m *mongo) AbandonMeeting(id string, u auth.User) (impact.Meeting, error) {
+	if err := m.setMeetingStatus(id, impact.ABANDONED, u); err != nil {
+		return impact.Meeting{}, err
+	}
+	return m.GetMeeting(id, u)
+}
+
+func (m *mongo) setMeetingDeleted(id string, deleted bool, u auth.User) error {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return err
+	}
+
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	meeting := impact.Meeting{}
+	if _, err := col.Find(bson.M{
+		"_id":            id,
+		"organisationID": userOrg,
+	}).Apply(mgo.Change{
+		Update: bson.M{
+			"$set": bson.M{
+				"deleted":  deleted,
+				"modified": time.Now(),
+			},
+		},
+	}, &meeting); err != nil {
+		if mgo.ErrNotFound == err {
+			return data.NewNotFoundError("Meeting")
+		}
+		return err
+	}
+	m.refreshBeneficiaryAfterChange(userOrg, meeting.Beneficiary)
+	return nil
+}
+
+func (m *mongo) DeleteMeeting(id string, u auth.User) error {
+	return m.setMeetingDeleted(id, true, u)
+}
+
+func (m *mongo) RestoreMeeting(id string, u auth.User) (impact.Meeting, error) {
+	if err := m.setMeetingDeleted(id, false, u); err != nil {
+		return impact.Meeting{}, err
+	}
+	return m.GetMeeting(id, u)
+}
+
+func (m *mongo) SetMeetingBaseline(id string, baseline bool, u auth.User) (impact.Meeting, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Meeting{}, err
+	}
+
+	meeting, err := m.GetMeeting(id, u)
+	if err != nil {
+		return impact.Meeting{}, err
+	}
+
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	if baseline {
+		if _, err := col.UpdateAll(bson.M{
+			"organisationID": userOrg,
+			"beneficiary":    meeting.Beneficiary,
+			"outcomeSetID":   meeting.OutcomeSetID,
+			"baseline":       true,
+			"_id":            bson.M{"$ne": id},
+		}, bson.M{
+			"$set": bson.M{
+				"baseline": false,
+				"modified": time.Now(),
+			},
+		}); err != nil {
+			return impact.Meeting{}, err
+		}
+	}
+
+	if err := col.Update(bson.M{
+		"_id":            id,
+		"organisationID": userOrg,
+		"deleted":        notDeleted,
+	}, bson.M{
+		"$set": bson.M{
+			"baseline": baseline,
+			"modified": time.Now(),
+		},
+	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Meeting{}, data.NewNotFoundError("Meeting")
+		}
+		return impact.Meeting{}, err
+	}
+	return m.GetMeeting(id, u)
 }
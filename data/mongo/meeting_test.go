@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"testing"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func getCompletedMeeting() impact.Meeting {
+	return impact.Meeting{
+		ID:     "M1",
+		Status: impact.COMPLETE,
+		Answers: []impact.Answer{{
+			QuestionID: "Q1",
+			Type:       impact.INT,
+			Answer:     3,
+		}},
+	}
+}
+
+func TestAnswerEditReopensMeeting(t *testing.T) {
+	answers := []impact.Answer{{
+		QuestionID: "Q1",
+		Type:       impact.INT,
+		Answer:     4,
+	}}
+	edited := impact.Meeting{}
+	if !applySet(t, getCompletedMeeting(), answersReplacement(answers, true), "", &edited) {
+		return
+	}
+	assert.Equal(t, impact.INPROGRESS, edited.GetStatus())
+	assert.Len(t, edited.Answers, 1)
+
+	added := impact.Meeting{}
+	if !applySet(t, getCompletedMeeting(), answersReplacement(answers, false), "", &added) {
+		return
+	}
+	assert.Equal(t, impact.COMPLETE, added.GetStatus())
+}
+
+func TestAnswerDeletionReopensMeeting(t *testing.T) {
+	update := answerDeletion("Q1")
+	assert.Equal(t, bson.M{"answers": bson.M{"questionID": "Q1"}}, update["$pull"])
+	edited := impact.Meeting{}
+	if !applySet(t, getCompletedMeeting(), update, "", &edited) {
+		return
+	}
+	assert.Equal(t, impact.INPROGRESS, edited.GetStatus())
+}
@@ -0,0 +1,156 @@
+package mongo
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// meetingCursor identifies a position within an ordered list of meetings.
+// The meeting ID breaks ties between meetings with the same sort value.
+type meetingCursor struct {
+	value time.Time
+	id    string
+}
+
+func encodeMeetingCursor(c meetingCursor) string {
+	raw := fmt.Sprintf("%d:%s", c.value.UnixNano()/int64(time.Millisecond), c.id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeMeetingCursor(s string) (meetingCursor, error) {
+	invalid := impact.ValidationErrors{{Field: "after", Message: "Invalid cursor"}}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return meetingCursor{}, invalid
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return meetingCursor{}, invalid
+	}
+	ms, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return meetingCursor{}, invalid
+	}
+	return meetingCursor{
+		value: time.Unix(0, ms*int64(time.Millisecond)),
+		id:    parts[1],
+	}, nil
+}
+
+func meetingSortValue(m impact.Meeting, field data.MeetingSortField) time.Time {
+	if field == data.SortByCreated {
+		return m.Created
+	}
+	return m.Conducted
+}
+
+func meetingFilterQuery(filter data.MeetingFilter, userOrg string) bson.M {
+	query := bson.M{
+		"organisationID": userOrg,
+	}
+	if !filter.IncludeDeleted {
+		query["deleted"] = notDeleted
+	}
+	if filter.Beneficiary != "" {
+		query["beneficiary"] = filter.Beneficiary
+	}
+	if filter.OutcomeSetID != "" {
+		query["outcomeSetID"] = filter.OutcomeSetID
+	}
+	if filter.User != "" {
+		query["user"] = filter.User
+	}
+	if filter.BeneficiaryPrefix != "" && filter.Beneficiary == "" {
+		query["beneficiary"] = bson.RegEx{Pattern: "^" + regexp.QuoteMeta(filter.BeneficiaryPrefix)}
+	}
+	conducted := bson.M{}
+	if filter.ConductedAfter != nil {
+		conducted["$gte"] = *filter.ConductedAfter
+	}
+	if filter.ConductedBefore != nil {
+		conducted["$lte"] = *filter.ConductedBefore
+	}
+	if len(conducted) > 0 {
+		query["conducted"] = conducted
+	}
+	return query
+}
+
+// GetMeetings returns a page of the organisation's meetings matching the filter.
+// Meetings are ordered by the requested field, ties are broken using the meeting ID.
+func (m *mongo) GetMeetings(filter data.MeetingFilter, page data.MeetingPageRequest, u auth.User) (data.MeetingPage, error) {
+	if page.First < 1 || page.First > data.MaxMeetingPageSize {
+		return data.MeetingPage{}, impact.ValidationErrors{{
+			Field:   "first",
+			Message: fmt.Sprintf("Must be between 1 and %d", data.MaxMeetingPageSize),
+		}}
+	}
+	sortBy := page.SortBy
+	if sortBy == "" {
+		sortBy = data.SortByConducted
+	}
+	if sortBy != data.SortByConducted && sortBy != data.SortByCreated {
+		return data.MeetingPage{}, impact.ValidationErrors{{Field: "sortBy", Message: "Unknown sort field"}}
+	}
+
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return data.MeetingPage{}, err
+	}
+
+	query := meetingFilterQuery(filter, userOrg)
+	comparison, direction := "$lt", "-"
+	if page.Ascending {
+		comparison, direction = "$gt", ""
+	}
+	if page.After != "" {
+		cursor, err := decodeMeetingCursor(page.After)
+		if err != nil {
+			return data.MeetingPage{}, err
+		}
+		query["$or"] = []bson.M{{
+			string(sortBy): bson.M{comparison: cursor.value},
+		}, {
+			string(sortBy): cursor.value,
+			"_id":          bson.M{comparison: cursor.id},
+		}}
+	}
+
+	col, closer := m.getMeetingCollection()
+	defer closer()
+
+	results := []impact.Meeting{}
+	if err := col.Find(query).
+		Sort(direction+string(sortBy), direction+"_id").
+		Limit(page.First + 1).
+		All(&results); err != nil {
+		return data.MeetingPage{}, err
+	}
+
+	ret := data.MeetingPage{
+		Edges:       make([]data.MeetingEdge, 0, page.First),
+		HasNextPage: len(results) > page.First,
+	}
+	if ret.HasNextPage {
+		results = results[:page.First]
+	}
+	for _, meeting := range results {
+		ret.Edges = append(ret.Edges, data.MeetingEdge{
+			Cursor: encodeMeetingCursor(meetingCursor{
+				value: meetingSortValue(meeting, sortBy),
+				id:    meeting.ID,
+			}),
+			Meeting: meeting,
+		})
+	}
+	return ret, nil
+}
@@ -9,9 +9,11 @@ import (
 
 type mongo struct {
 	baseSession *mgo.Session
+	// erasureKey keys the hashes of erased beneficiaries' IDs, erasure is disabled if it is empty
+	erasureKey []byte
 }
 
-func New(hostname string, port int, database, user, password string) (data.Base, error) {
+func dial(hostname string, port int, database, user, password string) (*mongo, error) {
 	url := fmt.Sprint(hostname, ":", port)
 	session, err := mgo.DialWithInfo(&mgo.DialInfo{
 		Addrs:    []string{url},
@@ -26,9 +28,24 @@ func New(hostname string, port int, database, user, password string) (data.Base,
 		return nil, err
 	}
 
-	m := &mongo{
+	return &mongo{
 		baseSession: session,
+	}, nil
+}
+
+// minErasureKeyLength is the minimum length of the secret used to key erased beneficiaries' hashes
+const minErasureKeyLength = 32
+
+// New connects to the mongo database. An empty erasure key disables beneficiary erasure.
+func New(hostname string, port int, database, user, password, erasureKey string) (data.Base, error) {
+	if erasureKey != "" && len(erasureKey) < minErasureKeyLength {
+		return nil, fmt.Errorf("The erasure key must be at least %d characters", minErasureKeyLength)
 	}
+	m, err := dial(hostname, port, database, user, password)
+	if err != nil {
+		return nil, err
+	}
+	m.erasureKey = []byte(erasureKey)
 	if err := m.ensureIndexes(); err != nil {
 		return nil, err
 	}
@@ -40,7 +57,55 @@ func (m *mongo) ensureIndexes() error {
 	defer osCloser()
 
 	if err := osCol.EnsureIndex(mgo.Index{
-		Key:    []string{"organisationID", "name"},
+		Key: []string{"organisationID", "name"},
+	}); err != nil {
+		return err
+	}
+
+	meetingCol, meetingCloser := m.getMeetingCollection()
+	defer meetingCloser()
+
+	// support the per beneficiary queries, the JOC report's outcome set and date range query and
+	// the filters and sort orders of the paginated meetings query
+	meetingIndexes := [][]string{
+		{"organisationID", "beneficiary", "conducted"},
+		{"organisationID", "outcomeSetID", "conducted", "_id"},
+		{"organisationID", "user", "conducted", "_id"},
+		{"organisationID", "conducted", "_id"},
+		{"organisationID", "created", "_id"},
+	}
+	for _, key := range meetingIndexes {
+		if err := meetingCol.EnsureIndex(mgo.Index{
+			Key: key,
+		}); err != nil {
+			return err
+		}
+	}
+
+	benCol, benCloser := m.getBeneficiaryCollection()
+	defer benCloser()
+
+	if err := benCol.EnsureIndex(mgo.Index{
+		Key:    []string{"organisationID", "id"},
+		Unique: true,
+	}); err != nil {
+		return err
+	}
+
+	changeCol, changeCloser := m.getBeneficiaryChangeCollection()
+	defer changeCloser()
+
+	if err := changeCol.EnsureIndex(mgo.Index{
+		Key: []string{"organisationID", "created"},
+	}); err != nil {
+		return err
+	}
+
+	erasureCol, erasureCloser := m.getErasureCollection()
+	defer erasureCloser()
+
+	if err := erasureCol.EnsureIndex(mgo.Index{
+		Key: []string{"organisationID", "created"},
 	}); err != nil {
 		return err
 	}
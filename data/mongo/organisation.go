@@ -1,11 +1,13 @@
 package mongo
 
 import (
+	"errors"
+
 	impact "github.com/impactasaurus/server"
 	"github.com/impactasaurus/server/auth"
 	"github.com/impactasaurus/server/data"
 	"gopkg.in/mgo.v2"
-	"errors"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func (m *mongo) GetOrganisation(id string, u auth.User) (impact.Organisation, error) {
@@ -32,3 +34,29 @@ func (m *mongo) GetOrganisation(id string, u auth.User) (impact.Organisation, er
 	}
 	return org, nil
 }
+
+// SetOrganisationTimeZone sets the time zone used when grouping the organisation's meetings by calendar period
+func (m *mongo) SetOrganisationTimeZone(timeZone string, u auth.User) (impact.Organisation, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Organisation{}, err
+	}
+	if err := impact.ValidateTimeZone(timeZone); err != nil {
+		return impact.Organisation{}, err
+	}
+
+	col, closer := m.getOrganisationCollection()
+	defer closer()
+
+	if err := col.UpdateId(userOrg, bson.M{
+		"$set": bson.M{
+			"timeZone": timeZone,
+		},
+	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Organisation{}, data.NewNotFoundError("Organisation")
+		}
+		return impact.Organisation{}, err
+	}
+	return m.GetOrganisation(userOrg, u)
+}
@@ -0,0 +1,184 @@
+package mongo
+
+import (
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+	"github.com/satori/go.uuid"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func (m *mongo) getProfileField(id string, u auth.User) (impact.ProfileField, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.ProfileField{}, err
+	}
+	org, err := m.GetOrganisation(userOrg, u)
+	if err != nil {
+		return impact.ProfileField{}, err
+	}
+	f := org.GetProfileField(id)
+	if f == nil {
+		return impact.ProfileField{}, data.NewNotFoundError("Profile field")
+	}
+	return *f, nil
+}
+
+func (m *mongo) NewProfileField(name, description string, fieldType impact.ProfileFieldType, options []string, u auth.User) (impact.ProfileField, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.ProfileField{}, err
+	}
+
+	field := impact.ProfileField{
+		ID:          uuid.NewV4().String(),
+		Name:        name,
+		Description: description,
+		Type:        fieldType,
+		Options:     options,
+	}
+	if err := field.Validate(); err != nil {
+		return impact.ProfileField{}, err
+	}
+
+	col, closer := m.getOrganisationCollection()
+	defer closer()
+
+	if err := col.UpdateId(userOrg, bson.M{
+		"$push": bson.M{
+			"profileFields": field,
+		},
+	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.ProfileField{}, data.NewNotFoundError("Organisation")
+		}
+		return impact.ProfileField{}, err
+	}
+	return field, nil
+}
+
+// EditProfileField updates the name, description and options of a profile field. The field's type cannot be changed.
+// Beneficiaries keep their existing values if an enum option is removed.
+func (m *mongo) EditProfileField(id, name, description string, options []string, u auth.User) (impact.ProfileField, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.ProfileField{}, err
+	}
+
+	field, err := m.getProfileField(id, u)
+	if err != nil {
+		return impact.ProfileField{}, err
+	}
+	field.Name = name
+	field.Description = description
+	field.Options = options
+	if err := field.Validate(); err != nil {
+		return impact.ProfileField{}, err
+	}
+
+	col, closer := m.getOrganisationCollection()
+	defer closer()
+
+	if err := col.Update(bson.M{
+		"_id":              userOrg,
+		"profileFields.id": id,
+	}, bson.M{
+		"$set": bson.M{
+			"profileFields.$": field,
+		},
+	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.ProfileField{}, data.NewNotFoundError("Profile field")
+		}
+		return impact.ProfileField{}, err
+	}
+	return field, nil
+}
+
+// DeleteProfileField marks the profile field as deleted. Beneficiaries' values for the field are retained.
+func (m *mongo) DeleteProfileField(id string, u auth.User) error {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return err
+	}
+
+	col, closer := m.getOrganisationCollection()
+	defer closer()
+
+	if err := col.Update(bson.M{
+		"_id":              userOrg,
+		"profileFields.id": id,
+	}, bson.M{
+		"$set": bson.M{
+			"profileFields.$.deleted": true,
+		},
+	}); err != nil {
+		if mgo.ErrNotFound == err {
+			return data.NewNotFoundError("Profile field")
+		}
+		return err
+	}
+	return nil
+}
+
+func (m *mongo) updateBeneficiaryProfile(beneficiaryID string, update bson.M, u auth.User) (impact.Beneficiary, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Beneficiary{}, err
+	}
+
+	col, closer := m.getBeneficiaryCollection()
+	defer closer()
+
+	if err := col.Update(bson.M{
+		"id":             beneficiaryID,
+		"organisationID": userOrg,
+	}, update); err != nil {
+		if mgo.ErrNotFound == err {
+			return impact.Beneficiary{}, data.NewNotFoundError("Beneficiary")
+		}
+		return impact.Beneficiary{}, err
+	}
+	return m.GetBeneficiary(beneficiaryID, u)
+}
+
+// SetBeneficiaryProfileValues stores the beneficiary's values for the provided profile fields, keyed by field ID.
+// Every value is validated against its field before any are stored.
+func (m *mongo) SetBeneficiaryProfileValues(beneficiaryID string, values map[string]interface{}, u auth.User) (impact.Beneficiary, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Beneficiary{}, err
+	}
+	org, err := m.GetOrganisation(userOrg, u)
+	if err != nil {
+		return impact.Beneficiary{}, err
+	}
+
+	toSet := bson.M{}
+	for fieldID, value := range values {
+		f := org.GetProfileField(fieldID)
+		if f == nil {
+			return impact.Beneficiary{}, data.NewNotFoundError("Profile field")
+		}
+		if err := f.ValidateValue(value); err != nil {
+			return impact.Beneficiary{}, err
+		}
+		toSet["profile."+fieldID] = value
+	}
+	if len(toSet) == 0 {
+		return m.GetBeneficiary(beneficiaryID, u)
+	}
+	return m.updateBeneficiaryProfile(beneficiaryID, bson.M{"$set": toSet}, u)
+}
+
+func (m *mongo) ClearBeneficiaryProfileValue(beneficiaryID, fieldID string, u auth.User) (impact.Beneficiary, error) {
+	if _, err := m.getProfileField(fieldID, u); err != nil {
+		return impact.Beneficiary{}, err
+	}
+	return m.updateBeneficiaryProfile(beneficiaryID, bson.M{
+		"$unset": bson.M{
+			"profile." + fieldID: "",
+		},
+	}, u)
+}
@@ -23,6 +23,37 @@ func (m *mongo) GetQuestion(outcomeSetID string, questionID string, u auth.User)
 }
 
 func (m *mongo) NewQuestion(outcomeSetID, question, description string, questionType impact.QuestionType, options map[string]interface{}, u auth.User) (impact.Question, error) {
+	return m.newQuestion(outcomeSetID, impact.Question{
+		Question:    question,
+		Description: description,
+		Type:        questionType,
+		Options:     options,
+	}, u)
+}
+
+func (m *mongo) NewChoiceQuestion(outcomeSetID, question, description string, questionType impact.QuestionType, choices []impact.Choice, u auth.User) (impact.Question, error) {
+	return m.newQuestion(outcomeSetID, impact.Question{
+		Question:    question,
+		Description: description,
+		Type:        questionType,
+		Options:     map[string]interface{}{},
+		Choices:     assignChoiceIDs(choices),
+	}, u)
+}
+
+// assignChoiceIDs generates IDs for any choices which do not already have one
+func assignChoiceIDs(choices []impact.Choice) []impact.Choice {
+	out := make([]impact.Choice, len(choices))
+	for i, c := range choices {
+		if c.ID == "" {
+			c.ID = uuid.NewV4().String()
+		}
+		out[i] = c
+	}
+	return out
+}
+
+func (m *mongo) newQuestion(outcomeSetID string, newQuestion impact.Question, u auth.User) (impact.Question, error) {
 	userOrg, err := u.Organisation()
 	if err != nil {
 		return impact.Question{}, err
@@ -32,15 +63,8 @@ func (m *mongo) NewQuestion(outcomeSetID, question, description string, question
 	defer closer()
 
 	id := uuid.NewV4()
-
-	newQuestion := &impact.Question{
-		ID:          id.String(),
-		Question:    question,
-		Description: description,
-		Type:        questionType,
-		Options:     options,
-		Deleted:     false,
-	}
+	newQuestion.ID = id.String()
+	newQuestion.Deleted = false
 
 	if err := col.Update(bson.M{
 		"_id":            outcomeSetID,
@@ -76,6 +100,19 @@ func (m *mongo) DeleteQuestion(outcomeSetID, questionID string, u auth.User) err
 	})
 }
 
+// questionEdit returns the update editing the question matched by the positional operator.
+// Fields are set individually so those not being edited, such as the question's category, are kept.
+func questionEdit(question, description string, questionType impact.QuestionType, options map[string]interface{}) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"questions.$.question":    question,
+			"questions.$.description": description,
+			"questions.$.type":        questionType,
+			"questions.$.options":     options,
+		},
+	}
+}
+
 func (m *mongo) EditQuestion(outcomeSetID, questionID, question, description string, questionType impact.QuestionType, options map[string]interface{}, u auth.User) (impact.Question, error) {
 	userOrg, err := u.Organisation()
 	if err != nil {
@@ -85,14 +122,24 @@ func (m *mongo) EditQuestion(outcomeSetID, questionID, question, description str
 	col, closer := m.getOutcomeCollection()
 	defer closer()
 
-	newQ := impact.Question{
-		ID:          questionID,
-		Question:    question,
-		Description: description,
-		Type:        questionType,
-		Options:     options,
-		Deleted:     false,
+	if err := col.Update(bson.M{
+		"_id":            outcomeSetID,
+		"organisationID": userOrg,
+		"questions.id":   questionID,
+	}, questionEdit(question, description, questionType, options)); err != nil {
+		return impact.Question{}, err
 	}
+	return m.GetQuestion(outcomeSetID, questionID, u)
+}
+
+func (m *mongo) EditChoiceQuestion(outcomeSetID, questionID, question, description string, choices []impact.Choice, u auth.User) (impact.Question, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return impact.Question{}, err
+	}
+
+	col, closer := m.getOutcomeCollection()
+	defer closer()
 
 	if err := col.Update(bson.M{
 		"_id":            outcomeSetID,
@@ -100,12 +147,14 @@ func (m *mongo) EditQuestion(outcomeSetID, questionID, question, description str
 		"questions.id":   questionID,
 	}, bson.M{
 		"$set": bson.M{
-			"questions.$": newQ,
+			"questions.$.question":    question,
+			"questions.$.description": description,
+			"questions.$.choices":     assignChoiceIDs(choices),
 		},
 	}); err != nil {
 		return impact.Question{}, err
 	}
-	return newQ, nil
+	return m.GetQuestion(outcomeSetID, questionID, u)
 }
 
 func (m *mongo) MoveQuestion(outcomeSetID, questionID string, newIndex uint, u auth.User) error {
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"strings"
+	"testing"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// applySet applies the update's $set to the original document, as mongo would, decoding the result into edited.
+// Only fields beginning with prefix are expected, the prefix is removed before setting the field.
+func applySet(t *testing.T, original interface{}, update bson.M, prefix string, edited interface{}) bool {
+	raw, err := bson.Marshal(original)
+	if !assert.Nil(t, err) {
+		return false
+	}
+	doc := bson.M{}
+	if !assert.Nil(t, bson.Unmarshal(raw, &doc)) {
+		return false
+	}
+	for k, v := range update["$set"].(bson.M) {
+		if !assert.True(t, strings.HasPrefix(k, prefix), k) {
+			return false
+		}
+		doc[strings.TrimPrefix(k, prefix)] = v
+	}
+	raw, err = bson.Marshal(doc)
+	if !assert.Nil(t, err) {
+		return false
+	}
+	return assert.Nil(t, bson.Unmarshal(raw, edited))
+}
+
+func TestQuestionEditKeepsCategory(t *testing.T) {
+	original := impact.Question{
+		ID:         "Q1",
+		Question:   "How are you?",
+		Type:       impact.LIKERT,
+		CategoryID: "C1",
+		Options:    map[string]interface{}{"minLabel": "Bad"},
+	}
+	update := questionEdit("How do you feel?", "Today", impact.LIKERT, map[string]interface{}{"minLabel": "Awful"})
+	edited := impact.Question{}
+	if !applySet(t, original, update, "questions.$.", &edited) {
+		return
+	}
+	assert.Equal(t, "C1", edited.CategoryID)
+	assert.Equal(t, "How do you feel?", edited.Question)
+	assert.Equal(t, "Today", edited.Description)
+	assert.Equal(t, "Awful", edited.Options["minLabel"])
+}
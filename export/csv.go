@@ -0,0 +1,176 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/logic"
+)
+
+// formulaPrefixes are the leading characters which cause spreadsheet applications to evaluate a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes text which a spreadsheet application would evaluate as a formula with a single quote,
+// so user entered beneficiary IDs, question names and answers can not inject formulas. Numbers are left unchanged.
+func escapeFormula(s string) string {
+	if s == "" || !strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return s
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s
+	}
+	return "'" + s
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+var jocHeader = []interface{}{"type", "id", "name", "category_id", "category", "beneficiaries", "first", "last", "delta"}
+
+// JOCCSV writes one row for each of the report's aggregated questions and categories,
+// detailing the first, last and delta values. Questions and categories are listed in the outcome set's order.
+func JOCCSV(w io.Writer, report *impact.JOCServiceReport, os impact.OutcomeSet) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvRecord(jocHeader)); err != nil {
+		return err
+	}
+	qSums := getQuestionSummaries(report.QuestionAggregates)
+	for _, q := range os.Questions {
+		r, ok := qSums[q.ID]
+		if !ok {
+			continue
+		}
+		row := append([]interface{}{"question", q.ID, q.Question, q.CategoryID, categoryName(os, q.CategoryID)}, r.count, r.first, r.last, r.delta)
+		if err := out.Write(csvRecord(row)); err != nil {
+			return err
+		}
+	}
+	cSums := getCategorySummaries(report.CategoryAggregates)
+	for _, c := range os.Categories {
+		r, ok := cSums[c.ID]
+		if !ok {
+			continue
+		}
+		row := append([]interface{}{"category", c.ID, c.Name, c.ID, c.Name}, r.count, r.first, r.last, r.delta)
+		if err := out.Write(csvRecord(row)); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// answerText describes the answer, choice answers are described by their choice labels
+func answerText(a impact.Answer, q *impact.Question) string {
+	if q != nil && (a.Type == impact.CHOICE || a.Type == impact.CHOICES) {
+		ids, err := a.ChoiceIDs()
+		if err == nil {
+			labels := make([]string, 0, len(ids))
+			for _, id := range ids {
+				if c := q.GetChoice(id); c != nil {
+					labels = append(labels, c.Label)
+				} else {
+					labels = append(labels, id)
+				}
+			}
+			return strings.Join(labels, "; ")
+		}
+	}
+	switch v := a.Answer.(type) {
+	case float32:
+		return formatFloat(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+var meetingHeader = []interface{}{"beneficiary", "meeting_id", "conducted", "status", "question_id", "question", "category_id", "category", "answer", "score"}
+
+// meetingRows returns one row for each of the meetings' answers, matching meetingHeader.
+// Rows are ordered by beneficiary, then by when the meeting was conducted.
+func meetingRows(meetings []impact.Meeting, os impact.OutcomeSet) [][]interface{} {
+	sorted := make([]impact.Meeting, len(meetings))
+	copy(sorted, meetings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Beneficiary != sorted[j].Beneficiary {
+			return sorted[i].Beneficiary < sorted[j].Beneficiary
+		}
+		return sorted[i].Conducted.Before(sorted[j].Conducted)
+	})
+
+	rows := [][]interface{}{}
+	for _, m := range sorted {
+		for _, a := range m.Answers {
+			q := os.GetQuestion(a.QuestionID)
+			name, catID := "", ""
+			var score *float32
+			if q != nil {
+				name, catID = q.Question, q.CategoryID
+				if v, ok := logic.GetAnswerValue(a, *q); ok {
+					score = &v
+				}
+			}
+			rows = append(rows, []interface{}{
+				m.Beneficiary,
+				m.ID,
+				m.Conducted.Format(time.RFC3339),
+				string(m.GetStatus()),
+				a.QuestionID,
+				name,
+				catID,
+				categoryName(os, catID),
+				answerText(a, q),
+				score,
+			})
+		}
+	}
+	return rows
+}
+
+// csvRecord formats the cells of a row, nil cells are left empty and text is escaped with escapeFormula
+func csvRecord(cells []interface{}) []string {
+	out := make([]string, 0, len(cells))
+	for _, c := range cells {
+		switch v := c.(type) {
+		case nil:
+			out = append(out, "")
+		case *float32:
+			if v == nil {
+				out = append(out, "")
+			} else {
+				out = append(out, formatFloat(*v))
+			}
+		case float32:
+			out = append(out, formatFloat(v))
+		default:
+			out = append(out, escapeFormula(fmt.Sprint(v)))
+		}
+	}
+	return out
+}
+
+// MeetingsCSV writes the meetings' answers in long format, one row per answer.
+// Question and category names are resolved using the outcome set.
+// Rows are ordered by beneficiary, then by when the meeting was conducted.
+func MeetingsCSV(w io.Writer, meetings []impact.Meeting, os impact.OutcomeSet) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvRecord(meetingHeader)); err != nil {
+		return err
+	}
+	for _, row := range meetingRows(meetings, os) {
+		if err := out.Write(csvRecord(row)); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
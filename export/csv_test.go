@@ -0,0 +1,137 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/export"
+	"github.com/stretchr/testify/assert"
+)
+
+func getOutcomeSet() impact.OutcomeSet {
+	score := func(f float32) *float32 {
+		return &f
+	}
+	return impact.OutcomeSet{
+		ID: "os",
+		Questions: []impact.Question{{
+			ID:         "Q1",
+			Question:   "How are you?",
+			Type:       impact.LIKERT,
+			CategoryID: "C1",
+		}, {
+			ID:         "Q2",
+			Question:   "Where do you live?",
+			Type:       impact.SINGLECHOICE,
+			CategoryID: "C1",
+			Choices: []impact.Choice{{
+				ID:    "A",
+				Label: "With family",
+				Score: score(2),
+			}, {
+				ID:    "B",
+				Label: "Alone, with pets",
+				Score: score(1),
+			}},
+		}, {
+			ID:       "Q3",
+			Question: "Anything else?",
+			Type:     impact.FREETEXT,
+		}},
+		Categories: []impact.Category{{
+			ID:          "C1",
+			Name:        "Wellbeing",
+			Aggregation: impact.MEAN,
+		}},
+	}
+}
+
+func TestJOCCSV(t *testing.T) {
+	report := &impact.JOCServiceReport{
+		QuestionAggregates: impact.JOCQAggs{
+			First: []impact.QBenAgg{{QuestionID: "Q1", Value: 2, BeneficiaryIDs: []string{"B1", "B2"}}},
+			Last:  []impact.QBenAgg{{QuestionID: "Q1", Value: 4.5, BeneficiaryIDs: []string{"B1", "B2"}}},
+			Delta: []impact.QBenAgg{{QuestionID: "Q1", Value: 2.5, BeneficiaryIDs: []string{"B1", "B2"}}},
+		},
+		CategoryAggregates: impact.JOCCatAggs{
+			First: []impact.CatBenAgg{{CategoryID: "C1", Value: 1.5, BeneficiaryIDs: []string{"B1"}}},
+			Last:  []impact.CatBenAgg{{CategoryID: "C1", Value: 1, BeneficiaryIDs: []string{"B1"}}},
+			Delta: []impact.CatBenAgg{{CategoryID: "C1", Value: -0.5, BeneficiaryIDs: []string{"B1"}}},
+		},
+	}
+
+	var out bytes.Buffer
+	assert.Nil(t, export.JOCCSV(&out, report, getOutcomeSet()))
+	assert.Equal(t, `type,id,name,category_id,category,beneficiaries,first,last,delta
+question,Q1,How are you?,C1,Wellbeing,2,2,4.5,2.5
+category,C1,Wellbeing,C1,Wellbeing,1,1.5,1,-0.5
+`, out.String())
+}
+
+func TestMeetingsCSV(t *testing.T) {
+	conducted := time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)
+	meetings := []impact.Meeting{{
+		ID:          "M2",
+		Beneficiary: "B1",
+		Conducted:   conducted.AddDate(0, 1, 0),
+		Status:      impact.INPROGRESS,
+		Answers: []impact.Answer{{
+			QuestionID: "Q2",
+			Type:       impact.CHOICE,
+			Answer:     "B",
+		}},
+	}, {
+		ID:          "M1",
+		Beneficiary: "B1",
+		Conducted:   conducted,
+		Answers: []impact.Answer{{
+			QuestionID: "Q1",
+			Type:       impact.INT,
+			Answer:     3,
+		}, {
+			QuestionID: "Q3",
+			Type:       impact.STRING,
+			Answer:     "Line one\nLine two",
+		}},
+	}}
+
+	var out bytes.Buffer
+	assert.Nil(t, export.MeetingsCSV(&out, meetings, getOutcomeSet()))
+	assert.Equal(t, `beneficiary,meeting_id,conducted,status,question_id,question,category_id,category,answer,score
+B1,M1,2017-03-01T10:00:00Z,complete,Q1,How are you?,C1,Wellbeing,3,3
+B1,M1,2017-03-01T10:00:00Z,complete,Q3,Anything else?,,,"Line one
+Line two",
+B1,M2,2017-04-01T10:00:00Z,inprogress,Q2,Where do you live?,C1,Wellbeing,"Alone, with pets",1
+`, out.String())
+}
+
+func TestMeetingsCSVEscapesFormulas(t *testing.T) {
+	meetings := []impact.Meeting{{
+		ID:          "M1",
+		Beneficiary: "=HYPERLINK(\"http://example.com\")",
+		Conducted:   time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC),
+		Answers: []impact.Answer{{
+			QuestionID: "Q3",
+			Type:       impact.STRING,
+			Answer:     "@SUM(A1:A2)",
+		}, {
+			QuestionID: "Q1",
+			Type:       impact.FLOAT,
+			Answer:     float32(-1.5),
+		}, {
+			QuestionID: "Q4",
+			Type:       impact.STRING,
+			Answer:     "\tcmd",
+		}},
+	}}
+
+	var out bytes.Buffer
+	assert.Nil(t, export.MeetingsCSV(&out, meetings, getOutcomeSet()))
+	assert.Equal(t, `beneficiary,meeting_id,conducted,status,question_id,question,category_id,category,answer,score
+"'=HYPERLINK(""http://example.com"")",M1,2017-03-01T10:00:00Z,complete,Q3,Anything else?,,,'@SUM(A1:A2),
+"'=HYPERLINK(""http://example.com"")",M1,2017-03-01T10:00:00Z,complete,Q1,How are you?,C1,Wellbeing,-1.5,-1.5
+"'=HYPERLINK(""http://example.com"")",M1,2017-03-01T10:00:00Z,complete,Q4,,,,'	cmd,
+`, out.String())
+}
@@ -0,0 +1,188 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	impact "github.com/impactasaurus/server"
+)
+
+const reportDateFormat = "2 January 2006"
+
+func formatValue(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', 2, 32)
+}
+
+var resultWidths = []float64{215, 70, 70, 70, 70}
+
+func resultRow(name string, s *aggregateSummary) []string {
+	return []string{name, strconv.Itoa(s.count), formatValue(s.first), formatValue(s.last), formatValue(s.delta)}
+}
+
+func writeReportTitle(doc *pdfDocument, d JOCData) {
+	r := d.Report
+	doc.paragraph(d.Organisation.Name, bold, 20, black)
+	doc.space(4)
+	subtitle := "Impact report"
+	if d.OutcomeSet.Name != "" {
+		subtitle += ": " + d.OutcomeSet.Name
+	}
+	doc.paragraph(subtitle, regular, 14, black)
+	doc.space(8)
+	doc.paragraph(fmt.Sprintf("Period: %s to %s", d.Start.Format(reportDateFormat), d.End.Format(reportDateFormat)), regular, 10, grey)
+	doc.paragraph(fmt.Sprintf("Beneficiaries: %d", len(r.BeneficiaryIDs)), regular, 10, grey)
+	baseline := "Baseline: " + strings.ToLower(strings.Replace(string(r.Filters.Baseline), "_", " ", -1))
+	if r.Filters.BaselineDate != nil {
+		baseline += " on " + r.Filters.BaselineDate.Format(reportDateFormat)
+	}
+	doc.paragraph(baseline, regular, 10, grey)
+	if r.Filters.CompletedOnly {
+		doc.paragraph("Only completed meetings are included", regular, 10, grey)
+	}
+	if len(r.Filters.IncludeTags) > 0 {
+		doc.paragraph("Beneficiaries tagged: "+strings.Join(r.Filters.IncludeTags, ", "), regular, 10, grey)
+	}
+	if len(r.Filters.ExcludeTags) > 0 {
+		doc.paragraph("Excluding beneficiaries tagged: "+strings.Join(r.Filters.ExcludeTags, ", "), regular, 10, grey)
+	}
+}
+
+// writeQuestionSet describes the outcome set's categories and questions.
+// Archived questions are only described if they are included in the report.
+func writeQuestionSet(doc *pdfDocument, d JOCData, qSums map[string]*aggregateSummary) {
+	os := d.OutcomeSet
+	doc.heading("Question set", 14)
+	if os.Description != "" {
+		doc.paragraph(os.Description, regular, 10, black)
+		doc.space(6)
+	}
+	if len(os.Categories) > 0 {
+		doc.heading("Categories", 11)
+		for _, c := range os.Categories {
+			doc.ensureSpace(30)
+			doc.paragraph(c.Name, bold, 10, black)
+			if c.Description != "" {
+				doc.indentedParagraph(10, c.Description, regular, 9, black)
+			}
+			doc.space(4)
+		}
+	}
+	doc.heading("Questions", 11)
+	for _, q := range os.Questions {
+		if _, ok := qSums[q.ID]; q.Deleted && !ok {
+			continue
+		}
+		doc.ensureSpace(30)
+		doc.paragraph(q.Question, bold, 10, black)
+		if q.Description != "" {
+			doc.indentedParagraph(10, q.Description, regular, 9, black)
+		}
+		if q.CategoryID != "" {
+			doc.indentedParagraph(10, "Category: "+categoryName(os, q.CategoryID), regular, 9, grey)
+		}
+		doc.space(4)
+	}
+}
+
+// getNotes lists the report's warnings and exclusions
+func getNotes(d JOCData, qSums, cSums map[string]*aggregateSummary) []string {
+	r := d.Report
+	os := d.OutcomeSet
+	notes := append([]string{}, r.Warnings...)
+	for _, id := range r.Excluded.QuestionIDs {
+		notes = append(notes, fmt.Sprintf("Excluded question %s: %s", questionLabel(os, id), excludedQuestionReason))
+	}
+	for _, id := range r.Excluded.CategoryIDs {
+		notes = append(notes, fmt.Sprintf("Excluded category %s: %s", categoryLabel(os, id), excludedCategoryReason))
+	}
+	for _, id := range r.Excluded.BeneficiaryIDs {
+		notes = append(notes, fmt.Sprintf("Excluded beneficiary %s: %s", id, excludedBeneficiaryReason))
+	}
+	for _, q := range os.Questions {
+		if sum, ok := qSums[q.ID]; ok {
+			for _, w := range sum.warnings {
+				notes = append(notes, fmt.Sprintf("Question %s: %s", questionLabel(os, q.ID), w))
+			}
+		}
+	}
+	for _, c := range os.Categories {
+		if sum, ok := cSums[c.ID]; ok {
+			for _, w := range sum.warnings {
+				notes = append(notes, fmt.Sprintf("Category %s: %s", categoryLabel(os, c.ID), w))
+			}
+		}
+	}
+	return notes
+}
+
+func questionLabel(os impact.OutcomeSet, id string) string {
+	if name := questionName(os, id); name != "" {
+		return fmt.Sprintf("%q", name)
+	}
+	return id
+}
+
+func categoryLabel(os impact.OutcomeSet, id string) string {
+	if name := categoryName(os, id); name != "" {
+		return fmt.Sprintf("%q", name)
+	}
+	return id
+}
+
+// JOCPDF writes the journey of change report as a printable A4 PDF.
+// The report describes the outcome set, tabulates the first, last and delta aggregates,
+// charts the change in each category and ends with notes detailing the report's warnings and exclusions.
+func JOCPDF(w io.Writer, d JOCData) error {
+	os := d.OutcomeSet
+	qSums := getQuestionSummaries(d.Report.QuestionAggregates)
+	cSums := getCategorySummaries(d.Report.CategoryAggregates)
+
+	doc := newPDFDocument()
+	writeReportTitle(doc, d)
+	writeQuestionSet(doc, d, qSums)
+
+	header := []string{"", "Beneficiaries", "First", "Last", "Change"}
+	catRows := [][]string{}
+	bars := []bar{}
+	for _, c := range os.Categories {
+		if sum, ok := cSums[c.ID]; ok {
+			catRows = append(catRows, resultRow(c.Name, sum))
+			bars = append(bars, bar{label: c.Name, value: sum.delta})
+		}
+	}
+	if len(catRows) > 0 {
+		doc.heading("Category results", 14)
+		header[0] = "Category"
+		doc.table(header, resultWidths, catRows)
+		doc.heading("Change in categories", 11)
+		doc.paragraph("The mean change of each category between the first and last meetings", regular, 9, grey)
+		doc.barChart(bars)
+	}
+
+	qRows := [][]string{}
+	for _, q := range os.Questions {
+		if sum, ok := qSums[q.ID]; ok {
+			qRows = append(qRows, resultRow(q.Question, sum))
+		}
+	}
+	doc.heading("Question results", 14)
+	if len(qRows) > 0 {
+		header[0] = "Question"
+		doc.table(header, resultWidths, qRows)
+	} else {
+		doc.paragraph("No questions could be included in the report", regular, 10, black)
+	}
+
+	doc.heading("Notes", 11)
+	notes := getNotes(d, qSums, cSums)
+	if len(notes) == 0 {
+		doc.paragraph("No beneficiaries, questions or categories were excluded from the report", regular, 8, grey)
+	}
+	for i, n := range notes {
+		doc.paragraph(fmt.Sprintf("%d. %s", i+1, n), regular, 8, grey)
+	}
+
+	return doc.writePDF(w, d.Organisation.Name+" - "+os.Name)
+}
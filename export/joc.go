@@ -0,0 +1,88 @@
+package export
+
+import (
+	"time"
+
+	impact "github.com/impactasaurus/server"
+)
+
+// JOCData holds everything needed to export a journey of change report
+type JOCData struct {
+	Organisation impact.Organisation
+	OutcomeSet   impact.OutcomeSet
+	Start        time.Time
+	End          time.Time
+	Report       *impact.JOCServiceReport
+	// Meetings are the raw meetings conducted between Start and End.
+	// Only the meetings of beneficiaries included in or excluded from the report are exported.
+	Meetings []impact.Meeting
+}
+
+// explanations given for the report's exclusions
+const (
+	excludedQuestionReason    = "The question is not numeric or no beneficiary answered it in both meetings"
+	excludedCategoryReason    = "No beneficiary answered questions in the category in both meetings"
+	excludedBeneficiaryReason = "No baseline meeting could be found for the beneficiary"
+)
+
+// aggregateSummary holds the values of a question or category's first, last and delta aggregates
+type aggregateSummary struct {
+	count                        int
+	first, last                  float32
+	delta, deltaMedian, deltaStd float32
+	change                       *impact.ChangeCounts
+	significance                 *impact.DeltaSignificance
+	warnings                     []string
+}
+
+func getQuestionSummaries(aggs impact.JOCQAggs) map[string]*aggregateSummary {
+	out := map[string]*aggregateSummary{}
+	for _, a := range aggs.First {
+		out[a.QuestionID] = &aggregateSummary{count: len(a.BeneficiaryIDs), first: a.Value, warnings: a.Warnings}
+	}
+	for _, a := range aggs.Last {
+		if s, ok := out[a.QuestionID]; ok {
+			s.last = a.Value
+		}
+	}
+	for _, a := range aggs.Delta {
+		if s, ok := out[a.QuestionID]; ok {
+			s.delta, s.deltaMedian, s.deltaStd = a.Value, a.Median, a.StdDev
+			s.change, s.significance = a.Change, a.Significance
+		}
+	}
+	return out
+}
+
+func getCategorySummaries(aggs impact.JOCCatAggs) map[string]*aggregateSummary {
+	out := map[string]*aggregateSummary{}
+	for _, a := range aggs.First {
+		out[a.CategoryID] = &aggregateSummary{count: len(a.BeneficiaryIDs), first: a.Value, warnings: a.Warnings}
+	}
+	for _, a := range aggs.Last {
+		if s, ok := out[a.CategoryID]; ok {
+			s.last = a.Value
+		}
+	}
+	for _, a := range aggs.Delta {
+		if s, ok := out[a.CategoryID]; ok {
+			s.delta, s.deltaMedian, s.deltaStd = a.Value, a.Median, a.StdDev
+			s.change, s.significance = a.Change, a.Significance
+		}
+	}
+	return out
+}
+
+func categoryName(os impact.OutcomeSet, categoryID string) string {
+	if c := os.GetCategory(categoryID); c != nil {
+		return c.Name
+	}
+	return ""
+}
+
+func questionName(os impact.OutcomeSet, questionID string) string {
+	if q := os.GetQuestion(questionID); q != nil {
+		return q.Question
+	}
+	return ""
+}
@@ -0,0 +1,352 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size and margins, in points
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	pageMargin   = 50.0
+	contentWidth = pageWidth - 2*pageMargin
+	footerHeight = 20.0
+)
+
+type pdfFont int
+
+const (
+	regular pdfFont = iota
+	bold
+)
+
+func (f pdfFont) resource() string {
+	if f == bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// helveticaWidths and helveticaBoldWidths are the widths of the printable ASCII characters, from space to tilde,
+// in thousandths of the font size. They are taken from the standard Adobe font metrics.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsi maps the characters outside of ASCII and Latin-1 which the standard fonts can display
+var winAnsi = map[rune]byte{
+	'€': 128, '‘': 145, '’': 146, '“': 147, '”': 148, '•': 149, '–': 150, '—': 151,
+}
+
+// encodeText converts text to the WinAnsi encoding used by the standard fonts.
+// Characters which cannot be displayed are replaced with a question mark.
+func encodeText(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 32 && r <= 126, r >= 160 && r <= 255:
+			out = append(out, byte(r))
+		case r == '\t' || r == '\n' || r == '\r':
+			out = append(out, ' ')
+		default:
+			if b, ok := winAnsi[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+func textWidth(s string, font pdfFont, size float64) float64 {
+	widths := &helveticaWidths
+	if font == bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range encodeText(s) {
+		if b >= 32 && b <= 126 {
+			total += widths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// wrapText splits text into lines no wider than width. Words longer than the width are placed on their own line.
+func wrapText(s string, font pdfFont, size, width float64) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && textWidth(candidate, font, size) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func escapePDFString(b []byte) string {
+	var out bytes.Buffer
+	for _, c := range b {
+		switch c {
+		case '\\', '(', ')':
+			out.WriteByte('\\')
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
+
+type rgb struct {
+	r, g, b float64
+}
+
+var (
+	black     = rgb{0, 0, 0}
+	grey      = rgb{0.45, 0.45, 0.45}
+	lightGrey = rgb{0.85, 0.85, 0.85}
+)
+
+// pdfDocument lays out content from the top of the page downwards, starting new pages as required.
+// Coordinates are in points, with y measured from the bottom of the page as in PDF.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - pageMargin
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// ensureSpace starts a new page if there is less than height remaining on the current page
+func (d *pdfDocument) ensureSpace(height float64) {
+	if d.y-height < pageMargin+footerHeight {
+		d.newPage()
+	}
+}
+
+func (d *pdfDocument) space(height float64) {
+	d.y -= height
+}
+
+func textOp(x, y float64, s string, font pdfFont, size float64, colour rgb) string {
+	return fmt.Sprintf("BT %.3f %.3f %.3f rg /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		colour.r, colour.g, colour.b, font.resource(), size, x, y, escapePDFString(encodeText(s)))
+}
+
+// drawText writes a single line of text with its baseline at y
+func (d *pdfDocument) drawText(x, y float64, s string, font pdfFont, size float64, colour rgb) {
+	d.page().WriteString(textOp(x, y, s, font, size, colour))
+}
+
+func (d *pdfDocument) drawLine(x1, y1, x2, y2 float64, colour rgb) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f RG 0.5 w %.2f %.2f m %.2f %.2f l S\n", colour.r, colour.g, colour.b, x1, y1, x2, y2)
+}
+
+func (d *pdfDocument) fillRect(x, y, width, height float64, colour rgb) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f\n", colour.r, colour.g, colour.b, x, y, width, height)
+}
+
+// paragraph writes wrapped text across the content width
+func (d *pdfDocument) paragraph(s string, font pdfFont, size float64, colour rgb) {
+	d.indentedParagraph(0, s, font, size, colour)
+}
+
+func (d *pdfDocument) indentedParagraph(indent float64, s string, font pdfFont, size float64, colour rgb) {
+	leading := size * 1.3
+	for _, line := range wrapText(s, font, size, contentWidth-indent) {
+		d.ensureSpace(leading)
+		d.y -= leading
+		d.drawText(pageMargin+indent, d.y+size*0.3, line, font, size, colour)
+	}
+}
+
+func (d *pdfDocument) heading(s string, size float64) {
+	d.ensureSpace(size * 4)
+	d.space(size * 0.6)
+	d.paragraph(s, bold, size, black)
+	d.space(size * 0.4)
+}
+
+// table writes rows of cells, wrapping text within the columns. Columns after the first are right aligned.
+// The header row is repeated when the table continues onto a new page.
+func (d *pdfDocument) table(header []string, widths []float64, rows [][]string) {
+	const size = 9.0
+	const leading = size * 1.3
+	const padding = 4.0
+	wrapRow := func(cells []string, font pdfFont) ([][]string, float64) {
+		wrapped := make([][]string, len(cells))
+		lines := 1
+		for i, c := range cells {
+			wrapped[i] = wrapText(c, font, size, widths[i]-2*padding)
+			if len(wrapped[i]) > lines {
+				lines = len(wrapped[i])
+			}
+		}
+		return wrapped, float64(lines)*leading + padding
+	}
+	drawRow := func(wrapped [][]string, height float64, font pdfFont) {
+		x := pageMargin
+		for i, cellLines := range wrapped {
+			for l, line := range cellLines {
+				lineY := d.y - float64(l+1)*leading + size*0.3
+				if i == 0 {
+					d.drawText(x+padding, lineY, line, font, size, black)
+				} else {
+					d.drawText(x+widths[i]-padding-textWidth(line, font, size), lineY, line, font, size, black)
+				}
+			}
+			x += widths[i]
+		}
+		d.y -= height
+		d.drawLine(pageMargin, d.y, pageMargin+contentWidth, d.y, lightGrey)
+	}
+
+	headerCells, headerHeight := wrapRow(header, bold)
+	d.ensureSpace(headerHeight + 2*leading)
+	drawRow(headerCells, headerHeight, bold)
+	for _, row := range rows {
+		cells, height := wrapRow(row, regular)
+		if d.y-height < pageMargin+footerHeight {
+			d.newPage()
+			drawRow(headerCells, headerHeight, bold)
+		}
+		drawRow(cells, height, regular)
+	}
+}
+
+// bar is a labelled value in a bar chart
+type bar struct {
+	label string
+	value float32
+}
+
+// barChart draws horizontal bars from a zero axis, positive values extend right in green and negative values left in red
+func (d *pdfDocument) barChart(bars []bar) {
+	const size = 9.0
+	const barHeight = 14.0
+	const gap = 6.0
+	const labelWidth = 160.0
+	const valueWidth = 40.0
+	var maxAbs float32
+	hasNegative := false
+	for _, b := range bars {
+		v := b.value
+		if v < 0 {
+			v = -v
+			hasNegative = true
+		}
+		if v > maxAbs {
+			maxAbs = v
+		}
+	}
+	if maxAbs == 0 {
+		maxAbs = 1
+	}
+	plotLeft := pageMargin + labelWidth + valueWidth
+	plotWidth := contentWidth - labelWidth - 2*valueWidth
+	axis := plotLeft
+	scale := plotWidth / float64(maxAbs)
+	if hasNegative {
+		axis = plotLeft + plotWidth/2
+		scale = plotWidth / 2 / float64(maxAbs)
+	}
+
+	d.ensureSpace(float64(len(bars))*(barHeight+gap) + gap)
+	for _, b := range bars {
+		d.ensureSpace(barHeight + gap)
+		d.y -= barHeight + gap
+		label := wrapText(b.label, regular, size, labelWidth-gap)[0]
+		d.drawText(pageMargin, d.y+barHeight/2-size*0.35, label, regular, size, black)
+		length := float64(b.value) * scale
+		value := formatValue(b.value)
+		if b.value >= 0 {
+			d.fillRect(axis, d.y, length, barHeight, rgb{0.20, 0.60, 0.35})
+			d.drawText(axis+length+gap/2, d.y+barHeight/2-size*0.35, value, regular, size, black)
+		} else {
+			d.fillRect(axis+length, d.y, -length, barHeight, rgb{0.80, 0.25, 0.25})
+			d.drawText(axis+length-gap/2-textWidth(value, regular, size), d.y+barHeight/2-size*0.35, value, regular, size, black)
+		}
+		d.drawLine(axis, d.y-gap/2, axis, d.y+barHeight+gap/2, grey)
+	}
+	d.space(gap)
+}
+
+// writePDF adds page numbers to the footer of each page and writes the document, it should only be called once
+func (d *pdfDocument) writePDF(w io.Writer, title string) error {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(content string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// objects 1 to 5 are the catalog, page tree, fonts and document information.
+	// Each page is followed by its content stream, so the page at index i is object 6+2i.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Impactasaurus) >>", escapePDFString(encodeText(title))))
+	for i, p := range d.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		p.WriteString(textOp(pageWidth-pageMargin-textWidth(footer, regular, 8), pageMargin/2, footer, regular, 8, grey))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, len(offsets)+2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.Len(), p.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := out.WriteTo(w)
+	return err
+}
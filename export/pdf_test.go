@@ -0,0 +1,96 @@
+package export_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/export"
+	"github.com/stretchr/testify/assert"
+)
+
+var objectRegexp = regexp.MustCompile(`(?m)^(\d+) 0 obj$`)
+var xrefEntryRegexp = regexp.MustCompile(`(?m)^(\d{10}) 00000 n $`)
+var startXrefRegexp = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+
+// checkPDFStructure ensures the cross reference table points at each of the document's objects
+func checkPDFStructure(t *testing.T, pdf []byte) {
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	startXref := startXrefRegexp.FindSubmatch(pdf)
+	if !assert.NotNil(t, startXref) {
+		return
+	}
+	xref, _ := strconv.Atoi(string(startXref[1]))
+	assert.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+	entries := xrefEntryRegexp.FindAllSubmatch(pdf[xref:], -1)
+	objects := objectRegexp.FindAllSubmatchIndex(pdf, -1)
+	if !assert.Len(t, entries, len(objects)) {
+		return
+	}
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(string(e[1]))
+		assert.Equal(t, objects[i][0], offset)
+		assert.Equal(t, strconv.Itoa(i+1), string(pdf[objects[i][2]:objects[i][3]]))
+	}
+}
+
+func TestJOCPDF(t *testing.T) {
+	d := getJOCData()
+	d.Organisation.Name = `Charity (UK) \ Wales`
+	d.OutcomeSet.Name = "Wellbeing survey"
+	d.Report.CategoryAggregates = impact.JOCCatAggs{
+		First: []impact.CatBenAgg{{CategoryID: "C1", Value: 3, BeneficiaryIDs: []string{"B1", "B2"}}},
+		Last:  []impact.CatBenAgg{{CategoryID: "C1", Value: 2, BeneficiaryIDs: []string{"B1", "B2"}}},
+		Delta: []impact.CatBenAgg{{CategoryID: "C1", Value: -1, BeneficiaryIDs: []string{"B1", "B2"}}},
+	}
+	var out bytes.Buffer
+	if !assert.Nil(t, export.JOCPDF(&out, d)) {
+		return
+	}
+	pdf := out.Bytes()
+	checkPDFStructure(t, pdf)
+	assert.Contains(t, out.String(), "/Count 1 ")
+	assert.Contains(t, out.String(), `(Charity \(UK\) \\ Wales)`)
+	assert.Contains(t, out.String(), "(Impact report: Wellbeing survey)")
+	assert.Contains(t, out.String(), "(Period: 1 January 2017 to 30 June 2017)")
+	assert.Contains(t, out.String(), "(How are you?)")
+	assert.Contains(t, out.String(), "(Wellbeing)")
+	assert.Contains(t, out.String(), "(-1.00)")
+	assert.Contains(t, out.String(), "(2.50)")
+	assert.Contains(t, out.String(), "(1. Could not include beneficiary B5 <system error>)")
+	assert.Contains(t, out.String(), "(4. Excluded beneficiary B4: No baseline meeting could be found for the beneficiary)")
+	assert.Contains(t, out.String(), "(5. Question \"How are you?\": Beneficiary B3 not included)")
+	assert.Contains(t, out.String(), "(Page 1 of 1)")
+}
+
+func TestJOCPDFPages(t *testing.T) {
+	d := getJOCData()
+	d.OutcomeSet.Questions = []impact.Question{}
+	d.Report.QuestionAggregates = impact.JOCQAggs{}
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("Q%d", i)
+		d.OutcomeSet.Questions = append(d.OutcomeSet.Questions, impact.Question{
+			ID:          id,
+			Question:    fmt.Sprintf("Question %d", i),
+			Description: "A description which is long enough that it has to be wrapped over more than one line of the page",
+			Type:        impact.LIKERT,
+		})
+		agg := impact.QBenAgg{QuestionID: id, Value: float32(i), BeneficiaryIDs: []string{"B1"}}
+		d.Report.QuestionAggregates.First = append(d.Report.QuestionAggregates.First, agg)
+		d.Report.QuestionAggregates.Last = append(d.Report.QuestionAggregates.Last, agg)
+		d.Report.QuestionAggregates.Delta = append(d.Report.QuestionAggregates.Delta, agg)
+	}
+	var out bytes.Buffer
+	if !assert.Nil(t, export.JOCPDF(&out, d)) {
+		return
+	}
+	checkPDFStructure(t, out.Bytes())
+	assert.Regexp(t, `/Count ([5-9]|\d\d) `, out.String())
+	assert.Contains(t, out.String(), "(Question 99)")
+	// the results table's header is repeated on each page it continues onto
+	assert.True(t, bytes.Count(out.Bytes(), []byte("(Question) Tj")) > 1)
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+)
+
+var aggregateHeader = []interface{}{
+	"Beneficiaries", "First mean", "Last mean", "Change mean", "Change median", "Change std dev",
+	"Improved", "Unchanged", "Declined",
+	"Paired t-test p", "Wilcoxon p", "Cohen's d", "95% CI lower", "95% CI upper",
+}
+
+func (s aggregateSummary) cells() []interface{} {
+	cells := []interface{}{s.count, s.first, s.last, s.delta, s.deltaMedian, s.deltaStd}
+	if s.change != nil {
+		cells = append(cells, s.change.Improved, s.change.Unchanged, s.change.Declined)
+	} else {
+		cells = append(cells, nil, nil, nil)
+	}
+	if s.significance != nil {
+		sig := s.significance
+		cells = append(cells, sig.PairedTTestP, sig.WilcoxonP, sig.CohensD, sig.CILower, sig.CIUpper)
+	} else {
+		cells = append(cells, nil, nil, nil, nil, nil)
+	}
+	return cells
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+func summarySheet(d JOCData) sheet {
+	r := d.Report
+	s := sheet{name: "Summary"}
+	s.addRow("Journey of change report", "")
+	s.addRow("Organisation", d.Organisation.Name)
+	s.addRow("Question set", d.OutcomeSet.Name)
+	s.addRow("Start", d.Start.Format(time.RFC3339))
+	s.addRow("End", d.End.Format(time.RFC3339))
+	s.addRow("Beneficiaries", len(r.BeneficiaryIDs))
+	s.addRow("Completed meetings only", yesNo(r.Filters.CompletedOnly))
+	s.addRow("Baseline", string(r.Filters.Baseline))
+	if r.Filters.BaselineDate != nil {
+		s.addRow("Baseline date", r.Filters.BaselineDate.Format(time.RFC3339))
+	}
+	s.addRow("Include tags", strings.Join(r.Filters.IncludeTags, ", "))
+	s.addRow("Exclude tags", strings.Join(r.Filters.ExcludeTags, ", "))
+	s.addRow("Warnings", len(r.Warnings))
+	s.addRow("Excluded questions", len(r.Excluded.QuestionIDs))
+	s.addRow("Excluded categories", len(r.Excluded.CategoryIDs))
+	s.addRow("Excluded beneficiaries", len(r.Excluded.BeneficiaryIDs))
+	return s
+}
+
+func questionSheet(d JOCData) sheet {
+	s := sheet{name: "Questions"}
+	s.addRow(append([]interface{}{"Question ID", "Question", "Category"}, aggregateHeader...)...)
+	summaries := getQuestionSummaries(d.Report.QuestionAggregates)
+	for _, q := range d.OutcomeSet.Questions {
+		if sum, ok := summaries[q.ID]; ok {
+			s.addRow(append([]interface{}{q.ID, q.Question, categoryName(d.OutcomeSet, q.CategoryID)}, sum.cells()...)...)
+		}
+	}
+	return s
+}
+
+func categorySheet(d JOCData) sheet {
+	s := sheet{name: "Categories"}
+	s.addRow(append([]interface{}{"Category ID", "Category", "Aggregation"}, aggregateHeader...)...)
+	summaries := getCategorySummaries(d.Report.CategoryAggregates)
+	for _, c := range d.OutcomeSet.Categories {
+		if sum, ok := summaries[c.ID]; ok {
+			s.addRow(append([]interface{}{c.ID, c.Name, string(c.Aggregation)}, sum.cells()...)...)
+		}
+	}
+	return s
+}
+
+func warningSheet(d JOCData) sheet {
+	r := d.Report
+	os := d.OutcomeSet
+	s := sheet{name: "Warnings and exclusions"}
+	s.addRow("Type", "ID", "Name", "Detail")
+	for _, w := range r.Warnings {
+		s.addRow("Warning", "", "", w)
+	}
+	for _, id := range r.Excluded.QuestionIDs {
+		s.addRow("Excluded question", id, questionName(os, id), excludedQuestionReason)
+	}
+	for _, id := range r.Excluded.CategoryIDs {
+		s.addRow("Excluded category", id, categoryName(os, id), excludedCategoryReason)
+	}
+	for _, id := range r.Excluded.BeneficiaryIDs {
+		s.addRow("Excluded beneficiary", id, "", excludedBeneficiaryReason)
+	}
+	qSums := getQuestionSummaries(r.QuestionAggregates)
+	for _, q := range os.Questions {
+		if sum, ok := qSums[q.ID]; ok {
+			for _, w := range sum.warnings {
+				s.addRow("Question warning", q.ID, q.Question, w)
+			}
+		}
+	}
+	cSums := getCategorySummaries(r.CategoryAggregates)
+	for _, c := range os.Categories {
+		if sum, ok := cSums[c.ID]; ok {
+			for _, w := range sum.warnings {
+				s.addRow("Category warning", c.ID, c.Name, w)
+			}
+		}
+	}
+	return s
+}
+
+// reportMeetings returns the meetings of the beneficiaries included in or excluded from the report.
+// Beneficiaries the report filtered out, such as by their tags, are left out.
+func reportMeetings(d JOCData) []impact.Meeting {
+	bens := map[string]bool{}
+	for _, b := range d.Report.BeneficiaryIDs {
+		bens[b] = true
+	}
+	for _, b := range d.Report.Excluded.BeneficiaryIDs {
+		bens[b] = true
+	}
+	out := make([]impact.Meeting, 0, len(d.Meetings))
+	for _, m := range d.Meetings {
+		if bens[m.Beneficiary] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func rawDataSheet(d JOCData) sheet {
+	s := sheet{name: "Raw data"}
+	s.addRow(meetingHeader...)
+	for _, row := range meetingRows(reportMeetings(d), d.OutcomeSet) {
+		s.addRow(row...)
+	}
+	return s
+}
+
+// JOCXLSX writes the journey of change report as an XLSX workbook.
+// The workbook contains summary, question, category, warnings and exclusions, and raw data sheets.
+func JOCXLSX(w io.Writer, d JOCData) error {
+	return writeXLSX(w, []sheet{
+		summarySheet(d),
+		questionSheet(d),
+		categorySheet(d),
+		warningSheet(d),
+		rawDataSheet(d),
+	})
+}
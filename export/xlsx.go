@@ -0,0 +1,170 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sheet is a worksheet of an XLSX workbook. The first row is styled as a header.
+// Cells can be strings, ints, float32s, float64s, *float32s or nil, nil pointers are written as empty cells.
+type sheet struct {
+	name string
+	rows [][]interface{}
+}
+
+func (s *sheet) addRow(cells ...interface{}) {
+	s.rows = append(s.rows, cells)
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const spreadsheetNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+const relationshipNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+const stylesXML = xmlHeader + `<styleSheet xmlns="` + spreadsheetNS + `">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// columnName converts a zero based column index to its spreadsheet name, 0 is A and 26 is AA
+func columnName(idx int) string {
+	name := ""
+	for idx >= 0 {
+		name = string(rune('A'+idx%26)) + name
+		idx = idx/26 - 1
+	}
+	return name
+}
+
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// sheetName removes the characters which are not allowed in worksheet names and limits it to 31 characters
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+	return name
+}
+
+func writeCell(w io.Writer, ref string, style int, value interface{}) error {
+	var number string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *float32:
+		if v == nil {
+			return nil
+		}
+		number = formatFloat(*v)
+	case float32:
+		number = formatFloat(v)
+	case float64:
+		number = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		number = strconv.Itoa(v)
+	case string:
+		_, err := fmt.Fprintf(w, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escapeXML(v))
+		return err
+	default:
+		return fmt.Errorf("Unsupported cell value %T", value)
+	}
+	_, err := fmt.Fprintf(w, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, number)
+	return err
+}
+
+func writeSheet(w io.Writer, s sheet) error {
+	if _, err := io.WriteString(w, xmlHeader+`<worksheet xmlns="`+spreadsheetNS+`"><sheetData>`); err != nil {
+		return err
+	}
+	for r, row := range s.rows {
+		style := 0
+		if r == 0 {
+			style = 1
+		}
+		if _, err := fmt.Fprintf(w, `<row r="%d">`, r+1); err != nil {
+			return err
+		}
+		for c, value := range row {
+			if err := writeCell(w, fmt.Sprintf("%s%d", columnName(c), r+1), style, value); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, `</row>`); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, `</sheetData></worksheet>`)
+	return err
+}
+
+// writeXLSX writes the sheets as an Office Open XML workbook
+func writeXLSX(w io.Writer, sheets []sheet) error {
+	var contentTypes, workbook, workbookRels bytes.Buffer
+	contentTypes.WriteString(xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xmlHeader + `<workbook xmlns="` + spreadsheetNS + `" xmlns:r="` + relationshipNS + `"><sheets>`)
+	workbookRels.WriteString(xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, s := range sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(sheetName(s.name)), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, n, relationshipNS, n)
+	}
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, len(sheets)+1, relationshipNS)
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+
+	z := zip.NewWriter(w)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relationshipNS + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, f := range files {
+		fw, err := z.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return err
+		}
+	}
+	for i, s := range sheets {
+		fw, err := z.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return err
+		}
+		if err := writeSheet(fw, s); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
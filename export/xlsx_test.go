@@ -0,0 +1,200 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/export"
+	"github.com/stretchr/testify/assert"
+)
+
+type xlsxCell struct {
+	Ref    string `xml:"r,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []xlsxCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+func readZipFile(t *testing.T, z *zip.Reader, name string) []byte {
+	for _, f := range z.File {
+		if f.Name != name {
+			continue
+		}
+		r, err := f.Open()
+		if !assert.Nil(t, err) {
+			t.FailNow()
+		}
+		defer r.Close()
+		b, err := ioutil.ReadAll(r)
+		if !assert.Nil(t, err) {
+			t.FailNow()
+		}
+		return b
+	}
+	t.Fatalf("%s not found in workbook", name)
+	return nil
+}
+
+// readSheet returns the worksheet's cells keyed by reference, such as A1
+func readSheet(t *testing.T, z *zip.Reader, idx int) map[string]string {
+	var ws xlsxWorksheet
+	if !assert.Nil(t, xml.Unmarshal(readZipFile(t, z, fmt.Sprintf("xl/worksheets/sheet%d.xml", idx)), &ws)) {
+		t.FailNow()
+	}
+	cells := map[string]string{}
+	for _, row := range ws.Rows {
+		for _, c := range row.Cells {
+			cells[c.Ref] = c.Value + c.Inline
+		}
+	}
+	return cells
+}
+
+func getJOCData() export.JOCData {
+	pValue := float32(0.04)
+	conducted := time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)
+	return export.JOCData{
+		Organisation: impact.Organisation{Name: "Charity"},
+		OutcomeSet:   getOutcomeSet(),
+		Start:        time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2017, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Report: &impact.JOCServiceReport{
+			BeneficiaryIDs: []string{"B1", "B2"},
+			QuestionAggregates: impact.JOCQAggs{
+				First: []impact.QBenAgg{{QuestionID: "Q1", Value: 2, BeneficiaryIDs: []string{"B1", "B2"}, Warnings: []string{"Beneficiary B3 not included"}}},
+				Last:  []impact.QBenAgg{{QuestionID: "Q1", Value: 4.5, BeneficiaryIDs: []string{"B1", "B2"}}},
+				Delta: []impact.QBenAgg{{
+					QuestionID:     "Q1",
+					Value:          2.5,
+					BeneficiaryIDs: []string{"B1", "B2"},
+					Change:         &impact.ChangeCounts{Improved: 2},
+					Significance:   &impact.DeltaSignificance{WilcoxonP: &pValue},
+				}},
+			},
+			CategoryAggregates: impact.JOCCatAggs{
+				First: []impact.CatBenAgg{},
+				Last:  []impact.CatBenAgg{},
+				Delta: []impact.CatBenAgg{},
+			},
+			Excluded: impact.Excluded{
+				CategoryIDs:    []string{"C1"},
+				QuestionIDs:    []string{"Q3"},
+				BeneficiaryIDs: []string{"B4"},
+			},
+			Filters: impact.JOCFilters{
+				Baseline: impact.FIRSTMEETING,
+			},
+			Warnings: []string{"Could not include beneficiary B5 <system error>"},
+		},
+		Meetings: []impact.Meeting{{
+			ID:          "M1",
+			Beneficiary: "B1",
+			Conducted:   conducted,
+			Answers: []impact.Answer{{
+				QuestionID: "Q1",
+				Type:       impact.INT,
+				Answer:     3,
+			}},
+		}},
+	}
+}
+
+func TestJOCXLSX(t *testing.T) {
+	var out bytes.Buffer
+	if !assert.Nil(t, export.JOCXLSX(&out, getJOCData())) {
+		return
+	}
+	z, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var wb xlsxWorkbook
+	assert.Nil(t, xml.Unmarshal(readZipFile(t, z, "xl/workbook.xml"), &wb))
+	names := []string{}
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Summary", "Questions", "Categories", "Warnings and exclusions", "Raw data"}, names)
+	readZipFile(t, z, "[Content_Types].xml")
+	readZipFile(t, z, "xl/styles.xml")
+
+	summary := readSheet(t, z, 1)
+	assert.Equal(t, "Organisation", summary["A2"])
+	assert.Equal(t, "Charity", summary["B2"])
+	assert.Equal(t, "2017-01-01T00:00:00Z", summary["B4"])
+
+	questions := readSheet(t, z, 2)
+	assert.Equal(t, "Question ID", questions["A1"])
+	assert.Equal(t, "How are you?", questions["B2"])
+	assert.Equal(t, "Wellbeing", questions["C2"])
+	assert.Equal(t, "2", questions["D2"])
+	assert.Equal(t, "2.5", questions["G2"])
+	assert.Equal(t, "2", questions["J2"])
+	assert.Equal(t, "", questions["M2"])
+	assert.Equal(t, "0.04", questions["N2"])
+
+	categories := readSheet(t, z, 3)
+	assert.Equal(t, "Category ID", categories["A1"])
+	assert.Equal(t, "", categories["A2"])
+
+	warnings := readSheet(t, z, 4)
+	assert.Equal(t, "Could not include beneficiary B5 <system error>", warnings["D2"])
+	assert.Equal(t, "Excluded question", warnings["A3"])
+	assert.Equal(t, "Anything else?", warnings["C3"])
+	assert.Equal(t, "Excluded category", warnings["A4"])
+	assert.Equal(t, "B4", warnings["B5"])
+	assert.Equal(t, "Question warning", warnings["A6"])
+
+	raw := readSheet(t, z, 5)
+	assert.Equal(t, "beneficiary", raw["A1"])
+	assert.Equal(t, "M1", raw["B2"])
+	assert.Equal(t, "3", raw["J2"])
+}
+
+func TestJOCXLSXRawDataFollowsReportFilters(t *testing.T) {
+	d := getJOCData()
+	// B9 was filtered out of the report by an excluded tag, so is in neither the report's beneficiaries nor its exclusions
+	d.Report.Filters.ExcludeTags = []string{"inactive"}
+	d.Meetings = append(d.Meetings, impact.Meeting{
+		ID:          "M9",
+		Beneficiary: "B9",
+		Conducted:   d.Meetings[0].Conducted,
+		Answers:     d.Meetings[0].Answers,
+	}, impact.Meeting{
+		ID:          "M4",
+		Beneficiary: "B4",
+		Conducted:   d.Meetings[0].Conducted,
+		Answers:     d.Meetings[0].Answers,
+	})
+	var out bytes.Buffer
+	if !assert.Nil(t, export.JOCXLSX(&out, d)) {
+		return
+	}
+	z, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	raw := readSheet(t, z, 5)
+	assert.Equal(t, "B1", raw["A2"])
+	assert.Equal(t, "B4", raw["A3"])
+	assert.Equal(t, "", raw["A4"])
+}
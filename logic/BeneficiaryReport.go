@@ -0,0 +1,165 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/log"
+)
+
+type BeneficiaryReportDatabase interface {
+	GetOutcomeSet(id string, u auth.User) (impact.OutcomeSet, error)
+	GetOSMeetingsForBeneficiary(beneficiary string, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
+}
+
+// changeTracker remembers the first and most recent value seen for each question or category
+type changeTracker struct {
+	first    map[string]float32
+	previous map[string]float32
+}
+
+func newChangeTracker() *changeTracker {
+	return &changeTracker{
+		first:    map[string]float32{},
+		previous: map[string]float32{},
+	}
+}
+
+// track records the value and returns the change from the first and previous values.
+// Both changes are nil if this is the first value for the ID.
+func (c *changeTracker) track(id string, value float32) (*float32, *float32) {
+	first, seen := c.first[id]
+	if !seen {
+		c.first[id] = value
+		c.previous[id] = value
+		return nil, nil
+	}
+	fromFirst := value - first
+	fromPrevious := value - c.previous[id]
+	c.previous[id] = value
+	return &fromFirst, &fromPrevious
+}
+
+type benReporter struct {
+	benID         string
+	questionSetID string
+	os            impact.OutcomeSet
+	u             auth.User
+	questions     *changeTracker
+	categories    *changeTracker
+	warnings      []string
+}
+
+func (b *benReporter) addWarning(warning string) {
+	b.warnings = append(b.warnings, warning)
+}
+
+func (b *benReporter) getQuestionValues(m impact.Meeting) []impact.BenQuestionValue {
+	activeQs := b.os.ActiveQuestions()
+	out := make([]impact.BenQuestionValue, 0, len(activeQs))
+	for _, q := range activeQs {
+		if !q.IsNumeric() {
+			continue
+		}
+		a := m.GetAnswer(q.ID)
+		if a == nil {
+			continue
+		}
+		if !isNumericAnswer(*a, q) {
+			b.addWarning(fmt.Sprintf("Question %s in meeting %s not included as the answer was not of an expected format", q.ID, m.ID))
+			continue
+		}
+		v, err := answerToFloat(*a, q)
+		if err != nil {
+			b.addWarning(fmt.Sprintf("Question %s in meeting %s not included as the answer was not of an expected format", q.ID, m.ID))
+			continue
+		}
+		fromFirst, fromPrevious := b.questions.track(q.ID, v)
+		out = append(out, impact.BenQuestionValue{
+			QuestionID:   q.ID,
+			Value:        v,
+			FromFirst:    fromFirst,
+			FromPrevious: fromPrevious,
+		})
+	}
+	return out
+}
+
+func (b *benReporter) getCategoryValues(m impact.Meeting) []impact.BenCategoryValue {
+	aggs, err := GetCategoryAggregates(m, b.os)
+	if err != nil {
+		b.addWarning(fmt.Sprintf("Categories in meeting %s not included because the category aggregation failed", m.ID))
+		log.Error(errors.New("BeneficiaryReport: Category aggregation failed"), map[string]string{
+			"ben":     b.benID,
+			"meeting": m.ID,
+			"qsetID":  b.questionSetID,
+			"uid":     b.u.UserID(),
+			"error":   err.Error(),
+		})
+		return []impact.BenCategoryValue{}
+	}
+	out := make([]impact.BenCategoryValue, 0, len(aggs))
+	for _, agg := range aggs {
+		fromFirst, fromPrevious := b.categories.track(agg.CategoryID, agg.Value)
+		out = append(out, impact.BenCategoryValue{
+			CategoryID:   agg.CategoryID,
+			Value:        agg.Value,
+			FromFirst:    fromFirst,
+			FromPrevious: fromPrevious,
+		})
+	}
+	return out
+}
+
+// GetBeneficiaryReport produces a time series of a beneficiary's meetings for the outcome set.
+// Each meeting includes the numeric question answers and category aggregates, along with how they have changed
+// since the beneficiary's first meeting and previous meeting. If completedOnly is set, in progress and abandoned meetings are ignored.
+func GetBeneficiaryReport(benID, questionSetID string, completedOnly bool, db BeneficiaryReportDatabase, u auth.User) (*impact.BeneficiaryReport, error) {
+	os, err := db.GetOutcomeSet(questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	meetings, err := db.GetOSMeetingsForBeneficiary(benID, questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]impact.Meeting, 0, len(meetings))
+	for _, m := range meetings {
+		if !completedOnly || m.IsComplete() {
+			filtered = append(filtered, m)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, errors.New("No meetings found for the beneficiary and question set")
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Conducted.Before(filtered[j].Conducted)
+	})
+
+	b := benReporter{
+		benID:         benID,
+		questionSetID: questionSetID,
+		os:            os,
+		u:             u,
+		questions:     newChangeTracker(),
+		categories:    newChangeTracker(),
+		warnings:      []string{},
+	}
+	ret := impact.BeneficiaryReport{
+		BeneficiaryID: benID,
+		QuestionSetID: questionSetID,
+		Meetings:      make([]impact.BeneficiaryReportMeeting, 0, len(filtered)),
+	}
+	for _, m := range filtered {
+		ret.Meetings = append(ret.Meetings, impact.BeneficiaryReportMeeting{
+			Meeting:    m,
+			Questions:  b.getQuestionValues(m),
+			Categories: b.getCategoryValues(m),
+		})
+	}
+	ret.Warnings = b.warnings
+	return &ret, nil
+}
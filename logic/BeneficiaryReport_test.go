@@ -0,0 +1,148 @@
+package logic_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/logic"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func f32(v float32) *float32 {
+	return &v
+}
+
+func getBenReportMeetings() []impact.Meeting {
+	start := time.Unix(100000, 0)
+	intAnswer := func(qID string, v int) impact.Answer {
+		return impact.Answer{QuestionID: qID, Type: impact.INT, Answer: v}
+	}
+	return []impact.Meeting{{
+		ID:           "M3",
+		Beneficiary:  "B1",
+		OutcomeSetID: questionSetID,
+		Conducted:    start.Add(time.Hour * 48),
+		Answers:      []impact.Answer{intAnswer("Q1", 4), intAnswer("Q3", 8)},
+	}, {
+		ID:           "M1",
+		Beneficiary:  "B1",
+		OutcomeSetID: questionSetID,
+		Conducted:    start,
+		Answers:      []impact.Answer{intAnswer("Q1", 2), intAnswer("Q3", 4)},
+	}, {
+		ID:           "M2",
+		Beneficiary:  "B1",
+		OutcomeSetID: questionSetID,
+		Conducted:    start.Add(time.Hour * 24),
+		Status:       impact.INPROGRESS,
+		Answers:      []impact.Answer{intAnswer("Q1", 5)},
+	}}
+}
+
+func TestBeneficiaryReport(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		meetings := getBenReportMeetings()
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(meetings, nil)
+
+		report, err := logic.GetBeneficiaryReport("B1", questionSetID, false, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, &impact.BeneficiaryReport{
+			BeneficiaryID: "B1",
+			QuestionSetID: questionSetID,
+			Meetings: []impact.BeneficiaryReportMeeting{{
+				Meeting: meetings[1],
+				Questions: []impact.BenQuestionValue{
+					{QuestionID: "Q1", Value: 2},
+					{QuestionID: "Q3", Value: 4},
+				},
+				Categories: []impact.BenCategoryValue{
+					{CategoryID: "C1", Value: 2},
+					{CategoryID: "C2", Value: 4},
+				},
+			}, {
+				Meeting: meetings[2],
+				Questions: []impact.BenQuestionValue{
+					{QuestionID: "Q1", Value: 5, FromFirst: f32(3), FromPrevious: f32(3)},
+				},
+				Categories: []impact.BenCategoryValue{
+					{CategoryID: "C1", Value: 5, FromFirst: f32(3), FromPrevious: f32(3)},
+				},
+			}, {
+				Meeting: meetings[0],
+				Questions: []impact.BenQuestionValue{
+					{QuestionID: "Q1", Value: 4, FromFirst: f32(2), FromPrevious: f32(-1)},
+					{QuestionID: "Q3", Value: 8, FromFirst: f32(4), FromPrevious: f32(4)},
+				},
+				Categories: []impact.BenCategoryValue{
+					{CategoryID: "C1", Value: 4, FromFirst: f32(2), FromPrevious: f32(-1)},
+					{CategoryID: "C2", Value: 8, FromFirst: f32(4), FromPrevious: f32(4)},
+				},
+			}},
+			Warnings: []string{},
+		}, report)
+	})
+}
+
+func TestBeneficiaryReportCompletedOnly(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(getBenReportMeetings(), nil)
+
+		report, err := logic.GetBeneficiaryReport("B1", questionSetID, true, mockDB, mockUser)
+		assert.NoError(t, err)
+		if assert.Len(t, report.Meetings, 2) {
+			assert.Equal(t, "M1", report.Meetings[0].Meeting.ID)
+			assert.Equal(t, "M3", report.Meetings[1].Meeting.ID)
+			assert.Equal(t, impact.BenQuestionValue{QuestionID: "Q1", Value: 4, FromFirst: f32(2), FromPrevious: f32(2)}, report.Meetings[1].Questions[0])
+		}
+	})
+}
+
+func TestBeneficiaryReportUnexpectedAnswer(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		meetings := []impact.Meeting{{
+			ID:           "M1",
+			Beneficiary:  "B1",
+			OutcomeSetID: questionSetID,
+			Answers: []impact.Answer{
+				{QuestionID: "Q1", Type: impact.STRING, Answer: "five"},
+				{QuestionID: "Q2", Type: impact.INT, Answer: 3},
+			},
+		}}
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(meetings, nil)
+
+		report, err := logic.GetBeneficiaryReport("B1", questionSetID, false, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, []impact.BenQuestionValue{{QuestionID: "Q2", Value: 3}}, report.Meetings[0].Questions)
+		assert.Equal(t, []impact.BenCategoryValue{{CategoryID: "C1", Value: 3}}, report.Meetings[0].Categories)
+		assert.Len(t, report.Warnings, 1)
+	})
+}
+
+func TestBeneficiaryReportNoMeetings(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return([]impact.Meeting{}, nil)
+
+		report, err := logic.GetBeneficiaryReport("B1", questionSetID, false, mockDB, mockUser)
+		assert.Nil(t, report)
+		assert.Error(t, err)
+	})
+}
+
+func TestBeneficiaryReportMeetingsError(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		e := errors.New("Mongo error")
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(nil, e)
+
+		report, err := logic.GetBeneficiaryReport("B1", questionSetID, false, mockDB, mockUser)
+		assert.Nil(t, report)
+		assert.EqualError(t, err, e.Error())
+	})
+}
@@ -15,6 +15,94 @@ type JOCDatabase interface {
 	GetOutcomeSet(id string, u auth.User) (impact.OutcomeSet, error)
 	GetOSMeetingsForBeneficiary(beneficiary string, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
 	GetOSMeetingsInTimeRange(start, end time.Time, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
+	GetBeneficiaries(includeArchived bool, u auth.User) ([]impact.Beneficiary, error)
+	GetOrganisation(id string, u auth.User) (impact.Organisation, error)
+}
+
+// JOCOptions configures how a journey of change report is produced
+type JOCOptions struct {
+	// CompletedOnly restricts the first and last meetings to meetings which have been completed
+	CompletedOnly bool
+	// IncludeTags restricts the report to beneficiaries with at least one of the tags
+	IncludeTags []string
+	// ExcludeTags removes beneficiaries with any of the tags from the report
+	ExcludeTags []string
+	// BreakdownFieldID is the ID of a beneficiary profile field. If set, the aggregates are also calculated
+	// separately for each group of beneficiaries sharing a value for the field
+	BreakdownFieldID string
+	// BreakdownBands split number and date breakdown fields into bands, see impact.ProfileGrouping.
+	// The ages of date fields are measured at the end of the report.
+	BreakdownBands []float64
+	// BreakdownPrefixLength groups text breakdown fields by their first characters
+	BreakdownPrefixLength int
+	// Baseline selects the meeting which is compared with each beneficiary's last meeting.
+	// Defaults to the beneficiary's first meeting.
+	Baseline impact.BaselineStrategy
+	// BaselineDate is the date used by the CLOSESTTODATE baseline strategy
+	BaselineDate *time.Time
+}
+
+func (o JOCOptions) baseline() impact.BaselineStrategy {
+	if o.Baseline == "" {
+		return impact.FIRSTMEETING
+	}
+	return o.Baseline
+}
+
+func (o JOCOptions) validate() error {
+	switch o.baseline() {
+	case impact.FIRSTMEETING, impact.FIRSTINRANGE, impact.MARKEDBASELINE:
+		return nil
+	case impact.CLOSESTTODATE:
+		if o.BaselineDate == nil {
+			return impact.ValidationErrors{{Field: "baselineDate", Message: "A baseline date is required when using the closest to date baseline"}}
+		}
+		return nil
+	default:
+		return impact.ValidationErrors{{Field: "baseline", Message: fmt.Sprintf("Unknown baseline strategy %s", o.Baseline)}}
+	}
+}
+
+func (o JOCOptions) filterByTags() bool {
+	return len(o.IncludeTags) > 0 || len(o.ExcludeTags) > 0
+}
+
+// matchesTags returns true if the beneficiary satisfies the tag filters
+func (o JOCOptions) matchesTags(b impact.Beneficiary) bool {
+	if len(o.IncludeTags) > 0 {
+		included := false
+		for _, t := range o.IncludeTags {
+			if b.HasTag(t) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, t := range o.ExcludeTags {
+		if b.HasTag(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o JOCOptions) filters() impact.JOCFilters {
+	nonNil := func(in []string) []string {
+		if in == nil {
+			return []string{}
+		}
+		return in
+	}
+	return impact.JOCFilters{
+		IncludeTags:   nonNil(o.IncludeTags),
+		ExcludeTags:   nonNil(o.ExcludeTags),
+		CompletedOnly: o.CompletedOnly,
+		Baseline:      o.baseline(),
+		BaselineDate:  o.BaselineDate,
+	}
 }
 
 type firstAndLastMeetings struct {
@@ -23,7 +111,10 @@ type firstAndLastMeetings struct {
 }
 
 type jocReporter struct {
+	start               time.Time
+	end                 time.Time
 	questionSetID       string
+	opts                JOCOptions
 	db                  JOCDatabase
 	u                   auth.User
 	globalWarnings      []string
@@ -31,12 +122,28 @@ type jocReporter struct {
 	excludedCategoryIDs []string
 	excludedQuestionIDs []string
 	excludedBenIDs      []string
+	bens                map[string]impact.Beneficiary
 }
 
 func (j *jocReporter) addGlobalWarning(warning string) {
 	j.globalWarnings = append(j.globalWarnings, warning)
 }
 
+// includeMeeting returns true if the meeting can be used as a first or last meeting
+func (j *jocReporter) includeMeeting(m impact.Meeting) bool {
+	return !j.opts.CompletedOnly || m.IsComplete()
+}
+
+func (j *jocReporter) filterMeetings(meetings []impact.Meeting) []impact.Meeting {
+	out := make([]impact.Meeting, 0, len(meetings))
+	for _, m := range meetings {
+		if j.includeMeeting(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
 func (j *jocReporter) getLastMeetingForEachBen(meetingsInRange []impact.Meeting) map[string]impact.Meeting {
 	lastMeetings := map[string]impact.Meeting{}
 	for _, meeting := range meetingsInRange {
@@ -53,6 +160,40 @@ func (j *jocReporter) getLastMeetingForEachBen(meetingsInRange []impact.Meeting)
 	return lastMeetings
 }
 
+// getBeneficiaries returns the organisation's beneficiaries keyed by ID, they are only fetched once per report
+func (j *jocReporter) getBeneficiaries() (map[string]impact.Beneficiary, error) {
+	if j.bens != nil {
+		return j.bens, nil
+	}
+	bens, err := j.db.GetBeneficiaries(true, j.u)
+	if err != nil {
+		return nil, err
+	}
+	j.bens = make(map[string]impact.Beneficiary, len(bens))
+	for _, b := range bens {
+		j.bens[b.ID] = b
+	}
+	return j.bens, nil
+}
+
+// filterBeneficiaries removes beneficiaries which do not match the report's tag filters
+func (j *jocReporter) filterBeneficiaries(lastMeetings map[string]impact.Meeting) (map[string]impact.Meeting, error) {
+	if !j.opts.filterByTags() {
+		return lastMeetings, nil
+	}
+	benLookup, err := j.getBeneficiaries()
+	if err != nil {
+		return nil, err
+	}
+	filtered := make(map[string]impact.Meeting, len(lastMeetings))
+	for ben, m := range lastMeetings {
+		if j.opts.matchesTags(benLookup[ben]) {
+			filtered[ben] = m
+		}
+	}
+	return filtered, nil
+}
+
 func (j *jocReporter) getFirstAndLastMeetings(lastMeetings map[string]impact.Meeting) map[string]firstAndLastMeetings {
 	firstAndLast := map[string]firstAndLastMeetings{}
 	for ben, lastMeeting := range lastMeetings {
@@ -68,6 +209,7 @@ func (j *jocReporter) getFirstAndLastMeetings(lastMeetings map[string]impact.Mee
 			})
 			continue
 		}
+		benMeetings = j.filterMeetings(benMeetings)
 		if len(benMeetings) == 0 {
 			j.addGlobalWarning(fmt.Sprintf("Could not include beneficiary %s as we could not find their first meeting. Please contact support.", ben))
 			log.Error(errors.New("No benificary meetings found"), map[string]string{
@@ -78,17 +220,11 @@ func (j *jocReporter) getFirstAndLastMeetings(lastMeetings map[string]impact.Mee
 			})
 			continue
 		}
-		// 	 find first meeting
-		var firstMeeting impact.Meeting
-		found := false
-		for _, meeting := range benMeetings {
-			if meeting.ID != lastMeeting.ID &&
-				(!found || firstMeeting.Conducted.After(meeting.Conducted)) {
-				firstMeeting = meeting
-				found = true
-			}
-		}
+		firstMeeting, found, laterCandidate := j.findBaseline(benMeetings, lastMeeting)
 		if !found {
+			if laterCandidate {
+				j.addGlobalWarning(fmt.Sprintf("Beneficiary %s was excluded as their baseline meeting was not conducted before their last meeting", ben))
+			}
 			j.excludedBenIDs = append(j.excludedBenIDs, ben)
 			continue
 		}
@@ -100,6 +236,51 @@ func (j *jocReporter) getFirstAndLastMeetings(lastMeetings map[string]impact.Mee
 	return firstAndLast
 }
 
+// findBaseline selects the meeting to compare with the beneficiary's last meeting using the report's baseline strategy.
+// Only meetings conducted before the last meeting are considered, otherwise the first and last meetings would be swapped.
+// Ties are resolved in favour of the earlier meeting.
+// found is false if no suitable meeting was found, laterCandidate is true if a meeting would have been suitable
+// had it not been conducted after the last meeting.
+func (j *jocReporter) findBaseline(benMeetings []impact.Meeting, lastMeeting impact.Meeting) (baseline impact.Meeting, found, laterCandidate bool) {
+	strategy := j.opts.baseline()
+	for _, meeting := range benMeetings {
+		if meeting.ID == lastMeeting.ID {
+			continue
+		}
+		switch strategy {
+		case impact.FIRSTINRANGE:
+			if meeting.Conducted.Before(j.start) || meeting.Conducted.After(j.end) {
+				continue
+			}
+		case impact.MARKEDBASELINE:
+			if !meeting.Baseline {
+				continue
+			}
+		}
+		if !meeting.Conducted.Before(lastMeeting.Conducted) {
+			laterCandidate = true
+			continue
+		}
+		better := !found || meeting.Conducted.Before(baseline.Conducted)
+		if found && strategy == impact.CLOSESTTODATE {
+			distance, best := absDuration(meeting.Conducted.Sub(*j.opts.BaselineDate)), absDuration(baseline.Conducted.Sub(*j.opts.BaselineDate))
+			better = distance < best || (distance == best && meeting.Conducted.Before(baseline.Conducted))
+		}
+		if better {
+			baseline = meeting
+			found = true
+		}
+	}
+	return baseline, found, laterCandidate
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
 type beneficiaryAggregation struct {
 	first         []float32
 	last          []float32
@@ -138,16 +319,14 @@ func (ba *beneficiaryAggregation) aggregateQuestions(j *jocReporter, aggs *impac
 		return
 	}
 	getBenAgg := func(toAdd []float32) impact.QBenAgg {
-		return impact.QBenAgg{
-			QuestionID:     ba.aggTarget,
-			Warnings:       ba.warnings,
-			BeneficiaryIDs: ba.beneficiaries,
-			Value:          mean(toAdd),
-		}
+		return newQBenAgg(ba.aggTarget, toAdd, ba.beneficiaries, ba.warnings)
 	}
 	aggs.First = append(aggs.First, getBenAgg(ba.first))
 	aggs.Last = append(aggs.Last, getBenAgg(ba.last))
-	aggs.Delta = append(aggs.Delta, getBenAgg(ba.diff))
+	delta := getBenAgg(ba.diff)
+	delta.Significance = getSignificance(ba.diff)
+	delta.Change = countChanges(ba.diff, 0)
+	aggs.Delta = append(aggs.Delta, delta)
 }
 
 func (ba *beneficiaryAggregation) aggregateCategories(j *jocReporter, aggs *impact.JOCCatAggs) {
@@ -156,16 +335,18 @@ func (ba *beneficiaryAggregation) aggregateCategories(j *jocReporter, aggs *impa
 		return
 	}
 	getBenAgg := func(toAdd []float32) impact.CatBenAgg {
-		return impact.CatBenAgg{
-			CategoryID:     ba.aggTarget,
-			Warnings:       ba.warnings,
-			BeneficiaryIDs: ba.beneficiaries,
-			Value:          mean(toAdd),
-		}
+		return newCatBenAgg(ba.aggTarget, toAdd, ba.beneficiaries, ba.warnings)
 	}
 	aggs.First = append(aggs.First, getBenAgg(ba.first))
 	aggs.Last = append(aggs.Last, getBenAgg(ba.last))
-	aggs.Delta = append(aggs.Delta, getBenAgg(ba.diff))
+	delta := getBenAgg(ba.diff)
+	delta.Significance = getSignificance(ba.diff)
+	var threshold *impact.ChangeThreshold
+	if c := j.os.GetCategory(ba.aggTarget); c != nil {
+		threshold = c.ChangeThreshold
+	}
+	delta.Change = countChanges(ba.diff, changeThreshold(threshold, ba.first))
+	aggs.Delta = append(aggs.Delta, delta)
 }
 
 func (j *jocReporter) getQuestionAggregations(firstAndLast map[string]firstAndLastMeetings) impact.JOCQAggs {
@@ -176,6 +357,10 @@ func (j *jocReporter) getQuestionAggregations(firstAndLast map[string]firstAndLa
 		Delta: make([]impact.QBenAgg, 0, len(activeQs)),
 	}
 	for _, q := range activeQs {
+		if !q.IsNumeric() {
+			j.excludedQuestionIDs = append(j.excludedQuestionIDs, q.ID)
+			continue
+		}
 		benAggregator := newBenAgg(q.ID, len(firstAndLast))
 		for ben, fl := range firstAndLast {
 			firstAnswer := fl.first.GetAnswer(q.ID)
@@ -184,12 +369,12 @@ func (j *jocReporter) getQuestionAggregations(firstAndLast map[string]firstAndLa
 				benAggregator.addBenificaryWarning(fmt.Sprintf("Beneficiary %s not included as the question was not answered in both the first and last meetings", ben))
 				continue
 			}
-			if !firstAnswer.IsNumeric() || !lastAnswer.IsNumeric() {
+			if !isNumericAnswer(*firstAnswer, q) || !isNumericAnswer(*lastAnswer, q) {
 				benAggregator.addBenificaryWarning(fmt.Sprintf("Beneficiary %s not included as the answers were not of an expected format", ben))
 				continue
 			}
-			fV, fE := firstAnswer.ToFloat()
-			lV, lE := lastAnswer.ToFloat()
+			fV, fE := answerToFloat(*firstAnswer, q)
+			lV, lE := answerToFloat(*lastAnswer, q)
 			if fE != nil || lE != nil {
 				benAggregator.addBenificaryWarning(fmt.Sprintf("Beneficiary %s not included as the answers were not of an expected format", ben))
 				continue
@@ -244,13 +429,110 @@ func (j *jocReporter) getBeneficiaryIDs(firstAndLast map[string]firstAndLastMeet
 	return bens
 }
 
-func GetJOCServiceReport(start, end time.Time, questionSetID string, db JOCDatabase, u auth.User) (*impact.JOCServiceReport, error) {
+// getBreakdown groups the beneficiaries by their value for the breakdown profile field and aggregates each group.
+// Banded groups are listed in band order, other groups by value. Beneficiaries without a value are grouped
+// under an empty value, which is listed last.
+func (j *jocReporter) getBreakdown(firstAndLast map[string]firstAndLastMeetings) (*impact.JOCBreakdown, error) {
+	if j.opts.BreakdownFieldID == "" {
+		return nil, nil
+	}
+	userOrg, err := j.u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+	org, err := j.db.GetOrganisation(userOrg, j.u)
+	if err != nil {
+		return nil, err
+	}
+	field := org.GetProfileField(j.opts.BreakdownFieldID)
+	if field == nil {
+		return nil, impact.ValidationErrors{{Field: "breakdownFieldID", Message: "Profile field not found"}}
+	}
+	grouping := impact.ProfileGrouping{
+		Bands:        j.opts.BreakdownBands,
+		PrefixLength: j.opts.BreakdownPrefixLength,
+		AsOf:         j.end,
+	}
+	if err := grouping.Validate(*field); err != nil {
+		return nil, err
+	}
+	bens, err := j.getBeneficiaries()
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := map[string]map[string]firstAndLastMeetings{}
+	ranks := map[string]int{}
+	for ben, fl := range firstAndLast {
+		label, rank := field.GroupLabel(bens[ben].Profile[field.ID], grouping)
+		if _, ok := grouped[label]; !ok {
+			grouped[label] = map[string]firstAndLastMeetings{}
+		}
+		grouped[label][ben] = fl
+		ranks[label] = rank
+	}
+	labels := make([]string, 0, len(grouped))
+	for label := range grouped {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(a, b int) bool {
+		if (labels[a] == "") != (labels[b] == "") {
+			return labels[b] == ""
+		}
+		if ranks[labels[a]] != ranks[labels[b]] {
+			return ranks[labels[a]] < ranks[labels[b]]
+		}
+		return labels[a] < labels[b]
+	})
+
+	ret := &impact.JOCBreakdown{
+		FieldID: field.ID,
+		Groups:  make([]impact.JOCBreakdownGroup, 0, len(labels)),
+	}
+	for _, label := range labels {
+		group := grouped[label]
+		sub := j.groupReporter()
+		qAggs := sub.getQuestionAggregations(group)
+		cAggs := sub.getCategoryAggregations(group)
+		ret.Groups = append(ret.Groups, impact.JOCBreakdownGroup{
+			Value:              label,
+			BeneficiaryIDs:     sub.getBeneficiaryIDs(group),
+			QuestionAggregates: qAggs,
+			CategoryAggregates: cAggs,
+			Excluded: impact.Excluded{
+				CategoryIDs:    sub.excludedCategoryIDs,
+				QuestionIDs:    sub.excludedQuestionIDs,
+				BeneficiaryIDs: sub.excludedBenIDs,
+			},
+		})
+	}
+	return ret, nil
+}
+
+// groupReporter returns a copy of the reporter which records exclusions and warnings separately,
+// allowing a subset of the beneficiaries to be aggregated
+func (j *jocReporter) groupReporter() *jocReporter {
+	sub := *j
+	sub.globalWarnings = []string{}
+	sub.excludedCategoryIDs = []string{}
+	sub.excludedQuestionIDs = []string{}
+	sub.excludedBenIDs = []string{}
+	return &sub
+}
+
+func GetJOCServiceReport(start, end time.Time, questionSetID string, opts JOCOptions, db JOCDatabase, u auth.User) (*impact.JOCServiceReport, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
 	os, err := db.GetOutcomeSet(questionSetID, u)
 	if err != nil {
 		return nil, err
 	}
 	j := jocReporter{
+		start:               start,
+		end:                 end,
 		questionSetID:       questionSetID,
+		opts:                opts,
 		db:                  db,
 		u:                   u,
 		os:                  os,
@@ -264,14 +546,22 @@ func GetJOCServiceReport(start, end time.Time, questionSetID string, db JOCDatab
 	if err != nil {
 		return nil, err
 	}
+	meetingsInRange = j.filterMeetings(meetingsInRange)
 	if len(meetingsInRange) == 0 {
 		return nil, errors.New("No meetings found for the question set within the given date range")
 	}
 
-	lastMeetings := j.getLastMeetingForEachBen(meetingsInRange)
+	lastMeetings, err := j.filterBeneficiaries(j.getLastMeetingForEachBen(meetingsInRange))
+	if err != nil {
+		return nil, err
+	}
 	firstAndLast := j.getFirstAndLastMeetings(lastMeetings)
 	qAggs := j.getQuestionAggregations(firstAndLast)
 	cAggs := j.getCategoryAggregations(firstAndLast)
+	breakdown, err := j.getBreakdown(firstAndLast)
+	if err != nil {
+		return nil, err
+	}
 
 	ret := impact.JOCServiceReport{
 		Excluded: impact.Excluded{
@@ -279,6 +569,8 @@ func GetJOCServiceReport(start, end time.Time, questionSetID string, db JOCDatab
 			QuestionIDs:    j.excludedQuestionIDs,
 			BeneficiaryIDs: j.excludedBenIDs,
 		},
+		Filters:            j.opts.filters(),
+		Breakdown:          breakdown,
 		BeneficiaryIDs:     j.getBeneficiaryIDs(firstAndLast),
 		CategoryAggregates: cAggs,
 		QuestionAggregates: qAggs,
@@ -246,67 +246,188 @@ func TestJOCReport(t *testing.T) {
 			QuestionIDs:    []string{},
 			BeneficiaryIDs: []string{},
 		},
+		Filters: impact.JOCFilters{
+			IncludeTags: []string{},
+			ExcludeTags: []string{},
+			Baseline:    impact.FIRSTMEETING,
+		},
 		QuestionAggregates: impact.JOCQAggs{
 			First: []impact.QBenAgg{{
 				QuestionID:     "Q1",
 				Value:          4,
+				Count:          3,
+				Median:         5,
+				StdDev:         2.6457512,
+				Min:            1,
+				Max:            6,
+				IQR:            2.5,
+				Histogram:      []impact.HistogramBin{{Min: 1, Max: 2.6666667, Count: 1}, {Min: 2.6666667, Max: 4.3333335, Count: 0}, {Min: 4.3333335, Max: 6, Count: 2}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				QuestionID:     "Q2",
 				Value:          3,
+				Count:          3,
+				Median:         2,
+				StdDev:         1.7320508,
+				Min:            2,
+				Max:            5,
+				IQR:            1.5,
+				Histogram:      []impact.HistogramBin{{Min: 2, Max: 3, Count: 2}, {Min: 3, Max: 4, Count: 0}, {Min: 4, Max: 5, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				QuestionID:     "Q3",
 				Value:          5,
+				Count:          3,
+				Median:         5,
+				StdDev:         2,
+				Min:            3,
+				Max:            7,
+				IQR:            2,
+				Histogram:      []impact.HistogramBin{{Min: 3, Max: 4.3333335, Count: 1}, {Min: 4.3333335, Max: 5.6666665, Count: 1}, {Min: 5.6666665, Max: 7, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				QuestionID:     "Q4",
 				Value:          4.3333335,
+				Count:          3,
+				Median:         4,
+				StdDev:         0.57735026,
+				Min:            4,
+				Max:            5,
+				IQR:            0.5,
+				Histogram:      []impact.HistogramBin{{Min: 4, Max: 4.3333335, Count: 2}, {Min: 4.3333335, Max: 4.6666665, Count: 0}, {Min: 4.6666665, Max: 5, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}},
 			Last: []impact.QBenAgg{{
 				QuestionID:     "Q1",
 				Value:          5.3333335,
+				Count:          3,
+				Median:         5,
+				StdDev:         3.5118847,
+				Min:            2,
+				Max:            9,
+				IQR:            3.5,
+				Histogram:      []impact.HistogramBin{{Min: 2, Max: 4.3333335, Count: 1}, {Min: 4.3333335, Max: 6.6666665, Count: 1}, {Min: 6.6666665, Max: 9, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				QuestionID:     "Q2",
 				Value:          5,
+				Count:          3,
+				Median:         5,
+				StdDev:         3,
+				Min:            2,
+				Max:            8,
+				IQR:            3,
+				Histogram:      []impact.HistogramBin{{Min: 2, Max: 4, Count: 1}, {Min: 4, Max: 6, Count: 1}, {Min: 6, Max: 8, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				QuestionID:     "Q3",
 				Value:          5.3333335,
+				Count:          3,
+				Median:         5,
+				StdDev:         2.5166116,
+				Min:            3,
+				Max:            8,
+				IQR:            2.5,
+				Histogram:      []impact.HistogramBin{{Min: 3, Max: 4.6666665, Count: 1}, {Min: 4.6666665, Max: 6.3333335, Count: 1}, {Min: 6.3333335, Max: 8, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				QuestionID:     "Q4",
 				Value:          5.3333335,
+				Count:          3,
+				Median:         5,
+				StdDev:         0.57735026,
+				Min:            5,
+				Max:            6,
+				IQR:            0.5,
+				Histogram:      []impact.HistogramBin{{Min: 5, Max: 5.3333335, Count: 2}, {Min: 5.3333335, Max: 5.6666665, Count: 0}, {Min: 5.6666665, Max: 6, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}},
 			Delta: []impact.QBenAgg{{
-				QuestionID:     "Q1",
-				Value:          1.3333334,
+				QuestionID: "Q1",
+				Value:      1.3333334,
+				Count:      3,
+				Median:     4,
+				StdDev:     4.618802,
+				Min:        -4,
+				Max:        4,
+				IQR:        4,
+				Histogram:  []impact.HistogramBin{{Min: -4, Max: -1.3333334, Count: 1}, {Min: -1.3333334, Max: 1.3333334, Count: 0}, {Min: 1.3333334, Max: 4, Count: 2}},
+				Change: &impact.ChangeCounts{
+					Improved:         2,
+					Unchanged:        0,
+					Declined:         1,
+					ImprovedPercent:  66.666664,
+					UnchangedPercent: 0,
+					DeclinedPercent:  33.333332,
+				},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
-				QuestionID:     "Q2",
-				Value:          2,
+				QuestionID: "Q2",
+				Value:      2,
+				Count:      3,
+				Median:     3,
+				StdDev:     1.7320508,
+				Min:        0,
+				Max:        3,
+				IQR:        1.5,
+				Histogram:  []impact.HistogramBin{{Min: 0, Max: 1, Count: 1}, {Min: 1, Max: 2, Count: 0}, {Min: 2, Max: 3, Count: 2}},
+				Change: &impact.ChangeCounts{
+					Improved:         2,
+					Unchanged:        1,
+					Declined:         0,
+					ImprovedPercent:  66.666664,
+					UnchangedPercent: 33.333332,
+					DeclinedPercent:  0,
+				},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
-				QuestionID:     "Q3",
-				Value:          0.33333334,
+				QuestionID: "Q3",
+				Value:      0.33333334,
+				Count:      3,
+				Median:     2,
+				StdDev:     3.785939,
+				Min:        -4,
+				Max:        3,
+				IQR:        3.5,
+				Histogram:  []impact.HistogramBin{{Min: -4, Max: -1.6666666, Count: 1}, {Min: -1.6666666, Max: 0.6666667, Count: 0}, {Min: 0.6666667, Max: 3, Count: 2}},
+				Change: &impact.ChangeCounts{
+					Improved:         2,
+					Unchanged:        0,
+					Declined:         1,
+					ImprovedPercent:  66.666664,
+					UnchangedPercent: 0,
+					DeclinedPercent:  33.333332,
+				},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
-				QuestionID:     "Q4",
-				Value:          1,
+				QuestionID: "Q4",
+				Value:      1,
+				Count:      3,
+				Median:     1,
+				StdDev:     1,
+				Min:        0,
+				Max:        2,
+				IQR:        1,
+				Histogram:  []impact.HistogramBin{{Min: 0, Max: 0.6666667, Count: 1}, {Min: 0.6666667, Max: 1.3333334, Count: 1}, {Min: 1.3333334, Max: 2, Count: 1}},
+				Change: &impact.ChangeCounts{
+					Improved:         2,
+					Unchanged:        1,
+					Declined:         0,
+					ImprovedPercent:  66.666664,
+					UnchangedPercent: 33.333332,
+					DeclinedPercent:  0,
+				},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}},
@@ -315,33 +436,91 @@ func TestJOCReport(t *testing.T) {
 			First: []impact.CatBenAgg{{
 				CategoryID:     "C1",
 				Value:          3.5,
+				Count:          3,
+				Median:         4,
+				StdDev:         1.8027756,
+				Min:            1.5,
+				Max:            5,
+				IQR:            1.75,
+				Histogram:      []impact.HistogramBin{{Min: 1.5, Max: 2.6666667, Count: 1}, {Min: 2.6666667, Max: 3.8333333, Count: 0}, {Min: 3.8333333, Max: 5, Count: 2}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				CategoryID:     "C2",
 				Value:          4.6666665,
+				Count:          3,
+				Median:         5,
+				StdDev:         1.040833,
+				Min:            3.5,
+				Max:            5.5,
+				IQR:            1,
+				Histogram:      []impact.HistogramBin{{Min: 3.5, Max: 4.1666665, Count: 1}, {Min: 4.1666665, Max: 4.8333335, Count: 0}, {Min: 4.8333335, Max: 5.5, Count: 2}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}},
 			Last: []impact.CatBenAgg{{
 				CategoryID:     "C1",
 				Value:          5.1666665,
+				Count:          3,
+				Median:         5,
+				StdDev:         3.2532036,
+				Min:            2,
+				Max:            8.5,
+				IQR:            3.25,
+				Histogram:      []impact.HistogramBin{{Min: 2, Max: 4.1666665, Count: 1}, {Min: 4.1666665, Max: 6.3333335, Count: 1}, {Min: 6.3333335, Max: 8.5, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
 				CategoryID:     "C2",
 				Value:          5.3333335,
+				Count:          3,
+				Median:         5.5,
+				StdDev:         1.2583058,
+				Min:            4,
+				Max:            6.5,
+				IQR:            1.25,
+				Histogram:      []impact.HistogramBin{{Min: 4, Max: 4.8333335, Count: 1}, {Min: 4.8333335, Max: 5.6666665, Count: 1}, {Min: 5.6666665, Max: 6.5, Count: 1}},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}},
 			Delta: []impact.CatBenAgg{{
-				CategoryID:     "C1",
-				Value:          1.6666666,
+				CategoryID: "C1",
+				Value:      1.6666666,
+				Count:      3,
+				Median:     3.5,
+				StdDev:     3.1754265,
+				Min:        -2,
+				Max:        3.5,
+				IQR:        2.75,
+				Histogram:  []impact.HistogramBin{{Min: -2, Max: -0.16666667, Count: 1}, {Min: -0.16666667, Max: 1.6666666, Count: 0}, {Min: 1.6666666, Max: 3.5, Count: 2}},
+				Change: &impact.ChangeCounts{
+					Improved:         2,
+					Unchanged:        0,
+					Declined:         1,
+					ImprovedPercent:  66.666664,
+					UnchangedPercent: 0,
+					DeclinedPercent:  33.333332,
+				},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}, {
-				CategoryID:     "C2",
-				Value:          0.6666667,
+				CategoryID: "C2",
+				Value:      0.6666667,
+				Count:      3,
+				Median:     1.5,
+				StdDev:     1.8929695,
+				Min:        -1.5,
+				Max:        2,
+				IQR:        1.75,
+				Histogram:  []impact.HistogramBin{{Min: -1.5, Max: -0.33333334, Count: 1}, {Min: -0.33333334, Max: 0.8333333, Count: 0}, {Min: 0.8333333, Max: 2, Count: 2}},
+				Change: &impact.ChangeCounts{
+					Improved:         2,
+					Unchanged:        0,
+					Declined:         1,
+					ImprovedPercent:  66.666664,
+					UnchangedPercent: 0,
+					DeclinedPercent:  33.333332,
+				},
 				BeneficiaryIDs: []string{"B1", "B2", "B3"},
 				Warnings:       []string{},
 			}},
@@ -355,8 +534,21 @@ func TestJOCReport(t *testing.T) {
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B2", questionSetID, mockUser).Return(b2Meetings, nil)
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B3", questionSetID, mockUser).Return(b3Meetings, nil)
 
-		result, err := logic.GetJOCServiceReport(start, end, questionSetID, mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
 		assert.NoError(t, err)
+		// significance values are checked against reference values in significance_test.go
+		for i, d := range result.QuestionAggregates.Delta {
+			if assert.NotNil(t, d.Significance) {
+				assert.Len(t, d.Significance.Warnings, 1)
+			}
+			result.QuestionAggregates.Delta[i].Significance = nil
+		}
+		for i, d := range result.CategoryAggregates.Delta {
+			if assert.NotNil(t, d.Significance) {
+				assert.Len(t, d.Significance.Warnings, 1)
+			}
+			result.CategoryAggregates.Delta[i].Significance = nil
+		}
 		assert.EqualValues(t, expected, *result)
 	})
 
@@ -366,7 +558,7 @@ func TestOutcomeSetError(t *testing.T) {
 	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
 		e := errors.New("Mongo error")
 		mockDB.EXPECT().GetOutcomeSet("q", mockUser).Return(impact.OutcomeSet{}, e)
-		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", logic.JOCOptions{}, mockDB, mockUser)
 		assert.Nil(t, result)
 		assert.EqualError(t, err, e.Error())
 	})
@@ -377,7 +569,7 @@ func TestMeetingsInRangeError(t *testing.T) {
 		e := errors.New("Mongo error")
 		mockDB.EXPECT().GetOutcomeSet("q", mockUser).Return(impact.OutcomeSet{}, nil)
 		mockDB.EXPECT().GetOSMeetingsInTimeRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, e)
-		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", logic.JOCOptions{}, mockDB, mockUser)
 		assert.Nil(t, result)
 		assert.EqualError(t, err, e.Error())
 	})
@@ -389,7 +581,7 @@ func TestNoMeetingsInRange(t *testing.T) {
 	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
 		mockDB.EXPECT().GetOutcomeSet("q", mockUser).Return(impact.OutcomeSet{}, nil)
 		mockDB.EXPECT().GetOSMeetingsInTimeRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(meetingsInRange, nil)
-		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", logic.JOCOptions{}, mockDB, mockUser)
 		assert.Nil(t, result)
 		assert.Error(t, err)
 	})
@@ -404,7 +596,7 @@ func TestUsersWithOnlyOneMeeting(t *testing.T) {
 		mockDB.EXPECT().GetOutcomeSet("q", mockUser).Return(impact.OutcomeSet{}, nil)
 		mockDB.EXPECT().GetOSMeetingsInTimeRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(meetingsInRange, nil)
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", gomock.Any(), mockUser).Return(meetingsInRange, nil)
-		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", logic.JOCOptions{}, mockDB, mockUser)
 		assert.NoError(t, err)
 		assert.Len(t, result.BeneficiaryIDs, 0)
 		assert.Len(t, result.Excluded.BeneficiaryIDs, 1)
@@ -412,6 +604,30 @@ func TestUsersWithOnlyOneMeeting(t *testing.T) {
 	})
 }
 
+func TestSingleBeneficiaryDistribution(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+	b1Meetings := []impact.Meeting{meetings["B1M1"], meetings["B1M2"]}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M2"]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
+		assert.NoError(t, err)
+		delta := result.QuestionAggregates.Delta[0]
+		assert.Equal(t, 1, delta.Count)
+		assert.Equal(t, float32(4), delta.Median)
+		assert.Equal(t, float32(0), delta.StdDev)
+		assert.Equal(t, float32(4), delta.Min)
+		assert.Equal(t, float32(4), delta.Max)
+		assert.Equal(t, float32(0), delta.IQR)
+		assert.Equal(t, []impact.HistogramBin{{Min: 4, Max: 4, Count: 1}}, delta.Histogram)
+	})
+}
+
 func TestCategoryWithNoQuestions(t *testing.T) {
 	end := time.Unix(10000, 0)
 	start := end.Add(-time.Hour * 24)
@@ -432,7 +648,7 @@ func TestCategoryWithNoQuestions(t *testing.T) {
 		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
 
-		result, err := logic.GetJOCServiceReport(start, end, questionSetID, mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
 		assert.NoError(t, err)
 		assert.EqualValues(t, []string{"C2"}, result.Excluded.CategoryIDs)
 		assert.Len(t, result.Warnings, 0)
@@ -457,7 +673,7 @@ func TestQuestionWithNoAnswers(t *testing.T) {
 		}
 	}
 
-	inRangeMeetings := []impact.Meeting{b1m1}
+	inRangeMeetings := []impact.Meeting{b1m2}
 	b1Meetings := []impact.Meeting{b1m1, b1m2}
 
 	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
@@ -465,7 +681,7 @@ func TestQuestionWithNoAnswers(t *testing.T) {
 		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
 
-		result, err := logic.GetJOCServiceReport(start, end, questionSetID, mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
 		assert.NoError(t, err)
 		assert.EqualValues(t, []string{questionRemoved}, result.Excluded.QuestionIDs)
 		assert.Len(t, result.Warnings, 0)
@@ -483,9 +699,9 @@ func TestBenMeetingMissingQuestion(t *testing.T) {
 	questionRemoved := b1m1.Answers[0].QuestionID
 	b1m1.Answers = b1m1.Answers[1:]
 
-	inRangeMeetings := []impact.Meeting{b1m1, meetings["B2M1"]}
+	inRangeMeetings := []impact.Meeting{meetings["B1M2"], meetings["B2M2"]}
 	b1Meetings := []impact.Meeting{b1m1, meetings["B1M2"]}
-	b2Meetings := []impact.Meeting{meetings["B2M1"], meetings["B1M2"]}
+	b2Meetings := []impact.Meeting{meetings["B2M1"], meetings["B2M2"]}
 
 	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
 		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
@@ -493,7 +709,7 @@ func TestBenMeetingMissingQuestion(t *testing.T) {
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B2", questionSetID, mockUser).Return(b2Meetings, nil)
 
-		result, err := logic.GetJOCServiceReport(start, end, questionSetID, mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
 		assert.NoError(t, err)
 		assert.Len(t, result.Excluded.QuestionIDs, 0)
 		assert.Len(t, result.Warnings, 0)
@@ -524,9 +740,9 @@ func TestBenMeetingFailure(t *testing.T) {
 	os := getDefaultOutcomeSet(questionSetID)
 	meetings := getDefaultMeetings(start, end, questionSetID)
 
-	inRangeMeetings := []impact.Meeting{meetings["B1M1"], meetings["B2M1"]}
+	inRangeMeetings := []impact.Meeting{meetings["B1M2"], meetings["B2M2"]}
 	b1Meetings := []impact.Meeting{meetings["B1M1"], meetings["B1M2"]}
-	b2Meetings := []impact.Meeting{meetings["B2M1"], meetings["B1M2"]}
+	b2Meetings := []impact.Meeting{meetings["B2M1"], meetings["B2M2"]}
 
 	e := errors.New("test error")
 
@@ -536,9 +752,617 @@ func TestBenMeetingFailure(t *testing.T) {
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
 		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B2", questionSetID, mockUser).Return(b2Meetings, e)
 
-		result, err := logic.GetJOCServiceReport(start, end, questionSetID, mockDB, mockUser)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
 		assert.NoError(t, err)
 		assert.Len(t, result.Warnings, 1)
 		assert.Regexp(t, regexp.MustCompile("Could not include beneficiary B2 due to an system error.*"), result.Warnings[0])
 	})
 }
+
+func TestFreeTextQuestion(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	os.Questions = append(os.Questions, impact.Question{
+		ID:         "Q5",
+		Type:       impact.FREETEXT,
+		CategoryID: "C1",
+	})
+	b1m1 := meetings["B1M1"]
+	b1m2 := meetings["B1M2"]
+	b1m1.Answers = append(b1m1.Answers, impact.Answer{
+		QuestionID: "Q5",
+		Type:       impact.STRING,
+		Answer:     "first",
+	})
+	b1m2.Answers = append(b1m2.Answers, impact.Answer{
+		QuestionID: "Q5",
+		Type:       impact.STRING,
+		Answer:     "last",
+	})
+
+	inRangeMeetings := []impact.Meeting{b1m2}
+	b1Meetings := []impact.Meeting{b1m1, b1m2}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
+
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"Q5"}, result.Excluded.QuestionIDs)
+		assert.Len(t, result.Excluded.CategoryIDs, 0)
+		assert.Len(t, result.Warnings, 0)
+		for _, cba := range result.CategoryAggregates.First {
+			if cba.CategoryID == "C1" {
+				assert.Equal(t, float32(5), cba.Value)
+				assert.Len(t, cba.Warnings, 0)
+			}
+		}
+	})
+}
+
+func TestChoiceQuestions(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	score := func(s float32) *float32 {
+		return &s
+	}
+	os.Questions = append(os.Questions, impact.Question{
+		ID:         "Q5",
+		Type:       impact.SINGLECHOICE,
+		CategoryID: "C1",
+		Choices: []impact.Choice{
+			{ID: "low", Score: score(1)},
+			{ID: "high", Score: score(3)},
+		},
+	}, impact.Question{
+		ID:   "Q6",
+		Type: impact.MULTICHOICE,
+		Choices: []impact.Choice{
+			{ID: "a", Score: score(1)},
+			{ID: "b", Score: score(2)},
+			{ID: "c", Score: score(4)},
+		},
+	}, impact.Question{
+		ID:   "Q7",
+		Type: impact.SINGLECHOICE,
+		Choices: []impact.Choice{
+			{ID: "unscored"},
+		},
+	})
+	b1m1 := meetings["B1M1"]
+	b1m2 := meetings["B1M2"]
+	b1m1.Answers = append(b1m1.Answers, impact.Answer{
+		QuestionID: "Q5",
+		Type:       impact.CHOICE,
+		Answer:     "low",
+	}, impact.Answer{
+		QuestionID: "Q6",
+		Type:       impact.CHOICES,
+		Answer:     []interface{}{"a"},
+	}, impact.Answer{
+		QuestionID: "Q7",
+		Type:       impact.CHOICE,
+		Answer:     "unscored",
+	})
+	b1m2.Answers = append(b1m2.Answers, impact.Answer{
+		QuestionID: "Q5",
+		Type:       impact.CHOICE,
+		Answer:     "high",
+	}, impact.Answer{
+		QuestionID: "Q6",
+		Type:       impact.CHOICES,
+		Answer:     []string{"b", "c"},
+	}, impact.Answer{
+		QuestionID: "Q7",
+		Type:       impact.CHOICE,
+		Answer:     "unscored",
+	})
+
+	inRangeMeetings := []impact.Meeting{b1m2}
+	b1Meetings := []impact.Meeting{b1m1, b1m2}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
+
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"Q7"}, result.Excluded.QuestionIDs)
+		for _, qba := range result.QuestionAggregates.Delta {
+			switch qba.QuestionID {
+			case "Q5":
+				assert.Equal(t, float32(2), qba.Value)
+			case "Q6":
+				assert.Equal(t, float32(5), qba.Value)
+			}
+		}
+		for _, cba := range result.CategoryAggregates.First {
+			if cba.CategoryID == "C1" {
+				assert.Equal(t, float32(11)/3, cba.Value)
+			}
+		}
+	})
+}
+
+func TestNumericQuestion(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	os.Questions = append(os.Questions, impact.Question{
+		ID:         "Q5",
+		Type:       impact.NUMERIC,
+		CategoryID: "C2",
+		Options: map[string]interface{}{
+			"minValue": 0.0,
+			"maxValue": 10.0,
+			"step":     0.5,
+		},
+	})
+	b1m1 := meetings["B1M1"]
+	b1m2 := meetings["B1M2"]
+	b1m1.Answers = append(b1m1.Answers, impact.Answer{
+		QuestionID: "Q5",
+		Type:       impact.FLOAT,
+		Answer:     6.5,
+	})
+	b1m2.Answers = append(b1m2.Answers, impact.Answer{
+		QuestionID: "Q5",
+		Type:       impact.FLOAT,
+		Answer:     8.0,
+	})
+
+	inRangeMeetings := []impact.Meeting{b1m2}
+	b1Meetings := []impact.Meeting{b1m1, b1m2}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
+
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Len(t, result.Excluded.QuestionIDs, 0)
+		for _, qba := range result.QuestionAggregates.Delta {
+			if qba.QuestionID == "Q5" {
+				assert.Equal(t, float32(1.5), qba.Value)
+			}
+		}
+		for _, cba := range result.CategoryAggregates.Last {
+			if cba.CategoryID == "C2" {
+				assert.Equal(t, float32(7), cba.Value)
+			}
+		}
+	})
+}
+
+func TestAnswerForUnknownQuestion(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	b1m1 := meetings["B1M1"]
+	b1m1.Answers = append(b1m1.Answers, impact.Answer{
+		QuestionID: "removed",
+		Type:       impact.INT,
+		Answer:     1,
+	})
+
+	inRangeMeetings := []impact.Meeting{meetings["B1M2"]}
+	b1Meetings := []impact.Meeting{b1m1, meetings["B1M2"]}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
+
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Len(t, result.Excluded.CategoryIDs, 0)
+		assert.Len(t, result.CategoryAggregates.First, 2)
+	})
+}
+
+func TestCompletedMeetingsOnly(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	b1m1 := meetings["B1M1"]
+	b1m1.Status = impact.ABANDONED
+	b3m3 := meetings["B3M3"]
+	b3m3.Status = impact.INPROGRESS
+
+	inRangeMeetings := []impact.Meeting{
+		meetings["B1M2"],
+		meetings["B2M1"],
+		meetings["B2M2"],
+		meetings["B3M2"],
+		b3m3,
+	}
+	b1Meetings := []impact.Meeting{b1m1, meetings["B1M2"]}
+	b2Meetings := []impact.Meeting{meetings["B2M1"], meetings["B2M2"]}
+	b3Meetings := []impact.Meeting{meetings["B3M1"], meetings["B3M2"], b3m3}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(b1Meetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B2", questionSetID, mockUser).Return(b2Meetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B3", questionSetID, mockUser).Return(b3Meetings, nil)
+
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{CompletedOnly: true}, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"B2", "B3"}, result.BeneficiaryIDs)
+		assert.Equal(t, []string{"B1"}, result.Excluded.BeneficiaryIDs)
+		q1First := result.QuestionAggregates.First[0]
+		assert.Equal(t, "Q1", q1First.QuestionID)
+		assert.Equal(t, float32(3.5), q1First.Value)
+		q1Last := result.QuestionAggregates.Last[0]
+		assert.Equal(t, "Q1", q1Last.QuestionID)
+		assert.Equal(t, float32(6), q1Last.Value)
+	})
+}
+
+func TestCompletedMeetingsOnlyNoneInRange(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+	b1m2 := meetings["B1M2"]
+	b1m2.Status = impact.INPROGRESS
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{b1m2}, nil)
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{CompletedOnly: true}, mockDB, mockUser)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestTagFilters(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	inRangeMeetings := []impact.Meeting{
+		meetings["B1M2"],
+		meetings["B2M1"],
+		meetings["B2M2"],
+		meetings["B3M2"],
+		meetings["B3M3"],
+	}
+	bens := []impact.Beneficiary{
+		{ID: "B1", Tags: []string{"youth"}},
+		{ID: "B2", Tags: []string{"youth", "left"}},
+		{ID: "B3", Tags: []string{"adult"}},
+	}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetBeneficiaries(true, mockUser).Return(bens, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M1"], meetings["B1M2"]}, nil)
+
+		opts := logic.JOCOptions{
+			IncludeTags: []string{"youth"},
+			ExcludeTags: []string{"left"},
+		}
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, opts, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"B1"}, result.BeneficiaryIDs)
+		assert.Len(t, result.Excluded.BeneficiaryIDs, 0)
+		assert.Equal(t, impact.JOCFilters{
+			IncludeTags: []string{"youth"},
+			ExcludeTags: []string{"left"},
+			Baseline:    impact.FIRSTMEETING,
+		}, result.Filters)
+	})
+}
+
+func TestTagFiltersBeneficiaryError(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		e := errors.New("Mongo error")
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M2"]}, nil)
+		mockDB.EXPECT().GetBeneficiaries(true, mockUser).Return(nil, e)
+
+		opts := logic.JOCOptions{ExcludeTags: []string{"left"}}
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, opts, mockDB, mockUser)
+		assert.Nil(t, result)
+		assert.EqualError(t, err, e.Error())
+	})
+}
+
+func TestProfileFieldBreakdown(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	inRangeMeetings := []impact.Meeting{
+		meetings["B1M2"],
+		meetings["B2M1"],
+		meetings["B2M2"],
+		meetings["B3M3"],
+	}
+	org := impact.Organisation{
+		ID: "org",
+		ProfileFields: []impact.ProfileField{{
+			ID:      "gender",
+			Type:    impact.ENUMFIELD,
+			Options: []string{"Female", "Male"},
+		}},
+	}
+	bens := []impact.Beneficiary{
+		{ID: "B1", Profile: map[string]interface{}{"gender": "Female"}},
+		{ID: "B2", Profile: map[string]interface{}{"gender": "Male"}},
+		{ID: "B3"},
+	}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M1"], meetings["B1M2"]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B2", questionSetID, mockUser).Return([]impact.Meeting{meetings["B2M1"], meetings["B2M2"]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B3", questionSetID, mockUser).Return([]impact.Meeting{meetings["B3M1"], meetings["B3M3"]}, nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(org, nil)
+		mockDB.EXPECT().GetBeneficiaries(true, mockUser).Return(bens, nil)
+
+		opts := logic.JOCOptions{BreakdownFieldID: "gender"}
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, opts, mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"B1", "B2", "B3"}, result.BeneficiaryIDs)
+		if assert.NotNil(t, result.Breakdown) {
+			assert.Equal(t, "gender", result.Breakdown.FieldID)
+			groups := result.Breakdown.Groups
+			if assert.Len(t, groups, 3) {
+				assert.Equal(t, "Female", groups[0].Value)
+				assert.Equal(t, []string{"B1"}, groups[0].BeneficiaryIDs)
+				assert.Equal(t, float32(5), groups[0].QuestionAggregates.First[0].Value)
+				assert.Equal(t, float32(9), groups[0].QuestionAggregates.Last[0].Value)
+				assert.Equal(t, float32(4), groups[0].QuestionAggregates.Delta[0].Value)
+				assert.Equal(t, "Male", groups[1].Value)
+				assert.Equal(t, []string{"B2"}, groups[1].BeneficiaryIDs)
+				assert.Equal(t, float32(-4), groups[1].QuestionAggregates.Delta[0].Value)
+				assert.Equal(t, "", groups[2].Value)
+				assert.Equal(t, []string{"B3"}, groups[2].BeneficiaryIDs)
+			}
+		}
+	})
+}
+
+func TestProfileFieldBreakdownBands(t *testing.T) {
+	end := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour * 24)
+	os := getDefaultOutcomeSet(questionSetID)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	inRangeMeetings := []impact.Meeting{
+		meetings["B1M2"],
+		meetings["B2M1"],
+		meetings["B2M2"],
+		meetings["B3M3"],
+	}
+	org := impact.Organisation{
+		ID: "org",
+		ProfileFields: []impact.ProfileField{{
+			ID:   "dob",
+			Type: impact.DATEFIELD,
+		}},
+	}
+	bens := []impact.Beneficiary{
+		{ID: "B1", Profile: map[string]interface{}{"dob": time.Date(1995, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+		{ID: "B2", Profile: map[string]interface{}{"dob": time.Date(2005, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+		{ID: "B3", Profile: map[string]interface{}{"dob": time.Date(2000, time.June, 2, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRangeMeetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M1"], meetings["B1M2"]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B2", questionSetID, mockUser).Return([]impact.Meeting{meetings["B2M1"], meetings["B2M2"]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B3", questionSetID, mockUser).Return([]impact.Meeting{meetings["B3M1"], meetings["B3M3"]}, nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(org, nil)
+		mockDB.EXPECT().GetBeneficiaries(true, mockUser).Return(bens, nil)
+
+		opts := logic.JOCOptions{BreakdownFieldID: "dob", BreakdownBands: []float64{18, 25}}
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, opts, mockDB, mockUser)
+		assert.NoError(t, err)
+		if assert.NotNil(t, result.Breakdown) {
+			groups := result.Breakdown.Groups
+			if assert.Len(t, groups, 2) {
+				// B3 turns 18 the day after the report ends
+				assert.Equal(t, "Under 18", groups[0].Value)
+				assert.Equal(t, []string{"B2", "B3"}, groups[0].BeneficiaryIDs)
+				assert.Equal(t, "18 to under 25", groups[1].Value)
+				assert.Equal(t, []string{"B1"}, groups[1].BeneficiaryIDs)
+			}
+		}
+	})
+}
+
+func TestProfileFieldBreakdownUnknownField(t *testing.T) {
+	end := time.Unix(10000, 0)
+	start := end.Add(-time.Hour * 24)
+	meetings := getDefaultMeetings(start, end, questionSetID)
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M2"]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return([]impact.Meeting{meetings["B1M1"], meetings["B1M2"]}, nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(impact.Organisation{ID: "org"}, nil)
+
+		opts := logic.JOCOptions{BreakdownFieldID: "unknown"}
+		result, err := logic.GetJOCServiceReport(start, end, questionSetID, opts, mockDB, mockUser)
+		assert.Nil(t, result)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+	})
+}
+
+func getBaselineMeetings(start, end time.Time) []impact.Meeting {
+	meeting := func(id string, conducted time.Time, value int, baseline bool) impact.Meeting {
+		return impact.Meeting{
+			ID:           id,
+			Beneficiary:  "B1",
+			OutcomeSetID: questionSetID,
+			Conducted:    conducted,
+			Baseline:     baseline,
+			Answers: []impact.Answer{{
+				QuestionID: "Q1",
+				Type:       impact.INT,
+				Answer:     value,
+			}},
+		}
+	}
+	return []impact.Meeting{
+		meeting("M1", start.Add(-time.Hour*48), 1, false),
+		meeting("M2", start.Add(time.Hour), 3, false),
+		meeting("M3", start.Add(time.Hour*2), 4, true),
+		meeting("M4", end, 9, false),
+	}
+}
+
+func getBaselineReport(t *testing.T, opts logic.JOCOptions, benMeetings []impact.Meeting) *impact.JOCServiceReport {
+	end := time.Unix(1000000, 0)
+	start := end.Add(-time.Hour * 24)
+	if benMeetings == nil {
+		benMeetings = getBaselineMeetings(start, end)
+	}
+
+	var result *impact.JOCServiceReport
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		inRange := []impact.Meeting{}
+		for _, m := range benMeetings {
+			if !m.Conducted.Before(start) && !m.Conducted.After(end) {
+				inRange = append(inRange, m)
+			}
+		}
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRange, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiary("B1", questionSetID, mockUser).Return(benMeetings, nil)
+
+		var err error
+		result, err = logic.GetJOCServiceReport(start, end, questionSetID, opts, mockDB, mockUser)
+		assert.NoError(t, err)
+	})
+	return result
+}
+
+func TestFirstMeetingBaseline(t *testing.T) {
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.FIRSTMEETING}, nil)
+	assert.Equal(t, float32(1), result.QuestionAggregates.First[0].Value)
+	assert.Equal(t, float32(9), result.QuestionAggregates.Last[0].Value)
+	assert.Equal(t, impact.FIRSTMEETING, result.Filters.Baseline)
+}
+
+func TestFirstInRangeBaseline(t *testing.T) {
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.FIRSTINRANGE}, nil)
+	assert.Equal(t, float32(3), result.QuestionAggregates.First[0].Value)
+	assert.Equal(t, float32(6), result.QuestionAggregates.Delta[0].Value)
+}
+
+func TestMarkedBaseline(t *testing.T) {
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.MARKEDBASELINE}, nil)
+	assert.Equal(t, float32(4), result.QuestionAggregates.First[0].Value)
+	assert.Equal(t, float32(5), result.QuestionAggregates.Delta[0].Value)
+}
+
+func TestMarkedBaselineMissing(t *testing.T) {
+	end := time.Unix(1000000, 0)
+	meetings := getBaselineMeetings(end.Add(-time.Hour*24), end)
+	meetings[2].Baseline = false
+
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.MARKEDBASELINE}, meetings)
+	assert.Len(t, result.BeneficiaryIDs, 0)
+	assert.Equal(t, []string{"B1"}, result.Excluded.BeneficiaryIDs)
+}
+
+func TestMarkedBaselineAfterLastMeeting(t *testing.T) {
+	end := time.Unix(1000000, 0)
+	meetings := getBaselineMeetings(end.Add(-time.Hour*24), end)
+	meetings[2].Baseline = false
+	later := meetings[0]
+	later.ID = "M5"
+	later.Conducted = end.Add(time.Hour * 48)
+	later.Baseline = true
+	meetings = append(meetings, later)
+
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.MARKEDBASELINE}, meetings)
+	assert.Len(t, result.BeneficiaryIDs, 0)
+	assert.Equal(t, []string{"B1"}, result.Excluded.BeneficiaryIDs)
+	if assert.Len(t, result.Warnings, 1) {
+		assert.Contains(t, result.Warnings[0], "B1")
+	}
+}
+
+func TestClosestToDateBaselineAfterEnd(t *testing.T) {
+	end := time.Unix(1000000, 0)
+	meetings := getBaselineMeetings(end.Add(-time.Hour*24), end)
+	later := meetings[0]
+	later.ID = "M5"
+	later.Conducted = end.Add(time.Hour * 48)
+	meetings = append(meetings, later)
+
+	date := end.Add(time.Hour * 72)
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.CLOSESTTODATE, BaselineDate: &date}, meetings)
+	assert.Equal(t, []string{"B1"}, result.BeneficiaryIDs)
+	assert.Equal(t, float32(4), result.QuestionAggregates.First[0].Value)
+	assert.Equal(t, float32(9), result.QuestionAggregates.Last[0].Value)
+	assert.Equal(t, float32(5), result.QuestionAggregates.Delta[0].Value)
+}
+
+func TestClosestToDateBaseline(t *testing.T) {
+	end := time.Unix(1000000, 0)
+	start := end.Add(-time.Hour * 24)
+
+	date := start.Add(-time.Hour * 30)
+	result := getBaselineReport(t, logic.JOCOptions{Baseline: impact.CLOSESTTODATE, BaselineDate: &date}, nil)
+	assert.Equal(t, float32(1), result.QuestionAggregates.First[0].Value)
+	assert.Equal(t, &date, result.Filters.BaselineDate)
+
+	// M2 and M3 are equally close, the earlier meeting is used
+	date = start.Add(time.Minute * 90)
+	result = getBaselineReport(t, logic.JOCOptions{Baseline: impact.CLOSESTTODATE, BaselineDate: &date}, nil)
+	assert.Equal(t, float32(3), result.QuestionAggregates.First[0].Value)
+}
+
+func TestClosestToDateBaselineWithoutDate(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", logic.JOCOptions{Baseline: impact.CLOSESTTODATE}, mockDB, mockUser)
+		assert.Nil(t, result)
+		if assert.IsType(t, impact.ValidationErrors{}, err) {
+			assert.Equal(t, "baselineDate", err.(impact.ValidationErrors)[0].Field)
+		}
+	})
+}
+
+func TestUnknownBaseline(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		result, err := logic.GetJOCServiceReport(time.Now(), time.Now(), "q", logic.JOCOptions{Baseline: "latest"}, mockDB, mockUser)
+		assert.Nil(t, result)
+		if assert.IsType(t, impact.ValidationErrors{}, err) {
+			assert.Equal(t, "baseline", err.(impact.ValidationErrors)[0].Field)
+		}
+	})
+}
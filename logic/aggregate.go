@@ -3,7 +3,10 @@ package logic
 import (
 	"errors"
 	"fmt"
+
 	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/log"
 )
 
 func mean(in []float32) float32 {
@@ -30,7 +33,57 @@ func aggregate(in []float32, aggregation impact.Aggregation) (float32, error) {
 	}
 }
 
+// isNumericAnswer returns true if the answer can be converted to a number using answerToFloat
+func isNumericAnswer(a impact.Answer, q impact.Question) bool {
+	switch a.Type {
+	case impact.CHOICE, impact.CHOICES:
+		return q.IsNumeric()
+	default:
+		return a.IsNumeric()
+	}
+}
+
+// answerToFloat converts an answer to a number.
+// Choice answers are converted to the score of the selected choice, multiple selected choices have their scores summed.
+func answerToFloat(a impact.Answer, q impact.Question) (float32, error) {
+	switch a.Type {
+	case impact.CHOICE, impact.CHOICES:
+		choiceIDs, err := a.ChoiceIDs()
+		if err != nil {
+			return 0, err
+		}
+		var total float32
+		for _, id := range choiceIDs {
+			c := q.GetChoice(id)
+			if c == nil {
+				return 0, fmt.Errorf("Couldn't find choice %s", id)
+			}
+			if c.Score == nil {
+				return 0, fmt.Errorf("Choice %s does not have a score", id)
+			}
+			total += *c.Score
+		}
+		return total, nil
+	default:
+		return a.ToFloat()
+	}
+}
+
+// GetAnswerValue returns the numeric value of an answer.
+// The returned bool is false if the answer is not numeric or could not be converted.
+func GetAnswerValue(a impact.Answer, q impact.Question) (float32, bool) {
+	if !isNumericAnswer(a, q) {
+		return 0, false
+	}
+	v, err := answerToFloat(a, q)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
 // GetCategoryAggregate aggregates multiple answers into a single value.
+// Non numeric answers, such as free text answers, and answers to questions which are not in the outcome set are ignored.
 // If the returned CategoryAggregate is nil, there were no answers available for the category.
 func GetCategoryAggregate(m impact.Meeting, categoryID string, os impact.OutcomeSet) (*impact.CategoryAggregate, error) {
 	c := os.GetCategory(categoryID)
@@ -40,8 +93,14 @@ func GetCategoryAggregate(m impact.Meeting, categoryID string, os impact.Outcome
 	vals := make([]float32, 0, len(m.Answers))
 	for _, a := range m.Answers {
 		q := os.GetQuestion(a.QuestionID)
+		if q == nil {
+			continue
+		}
 		if q.CategoryID == categoryID {
-			f, err := a.ToFloat()
+			if !isNumericAnswer(a, *q) {
+				continue
+			}
+			f, err := answerToFloat(a, *q)
 			if err != nil {
 				return nil, err
 			}
@@ -74,3 +133,81 @@ func GetCategoryAggregates(m impact.Meeting, os impact.OutcomeSet) ([]impact.Cat
 	}
 	return out, nil
 }
+
+// meetingAggregator aggregates the answers of a group of meetings across beneficiaries.
+// Each beneficiary should be represented by a single meeting.
+// Questions and categories without any answers in the meetings are not included.
+type meetingAggregator struct {
+	report        string
+	questionSetID string
+	u             auth.User
+	os            impact.OutcomeSet
+}
+
+func (a meetingAggregator) getQuestionAggregates(meetings []impact.Meeting) []impact.QBenAgg {
+	activeQs := a.os.ActiveQuestions()
+	out := make([]impact.QBenAgg, 0, len(activeQs))
+	for _, q := range activeQs {
+		if !q.IsNumeric() {
+			continue
+		}
+		values := make([]float32, 0, len(meetings))
+		bens := make([]string, 0, len(meetings))
+		warnings := []string{}
+		for _, m := range meetings {
+			a := m.GetAnswer(q.ID)
+			if a == nil {
+				continue
+			}
+			if !isNumericAnswer(*a, q) {
+				warnings = append(warnings, fmt.Sprintf("Beneficiary %s not included as the answer was not of an expected format", m.Beneficiary))
+				continue
+			}
+			v, err := answerToFloat(*a, q)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Beneficiary %s not included as the answer was not of an expected format", m.Beneficiary))
+				continue
+			}
+			values = append(values, v)
+			bens = append(bens, m.Beneficiary)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		out = append(out, newQBenAgg(q.ID, values, bens, warnings))
+	}
+	return out
+}
+
+func (a meetingAggregator) getCategoryAggregates(meetings []impact.Meeting) []impact.CatBenAgg {
+	out := make([]impact.CatBenAgg, 0, len(a.os.Categories))
+	for _, c := range a.os.Categories {
+		values := make([]float32, 0, len(meetings))
+		bens := make([]string, 0, len(meetings))
+		warnings := []string{}
+		for _, m := range meetings {
+			catAg, err := GetCategoryAggregate(m, c.ID, a.os)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Beneficiary %s not included because the category aggregation failed", m.Beneficiary))
+				log.Error(fmt.Errorf("%s: Category aggregation failed", a.report), map[string]string{
+					"ben":        m.Beneficiary,
+					"categoryID": c.ID,
+					"qsetID":     a.questionSetID,
+					"uid":        a.u.UserID(),
+					"meeting":    m.ID,
+				})
+				continue
+			}
+			if catAg == nil {
+				continue
+			}
+			values = append(values, catAg.Value)
+			bens = append(bens, m.Beneficiary)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		out = append(out, newCatBenAgg(c.ID, values, bens, warnings))
+	}
+	return out
+}
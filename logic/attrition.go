@@ -0,0 +1,147 @@
+package logic
+
+import (
+	"sort"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+)
+
+// defaultInactiveDays is used when the attrition report is not given an inactivity period
+const defaultInactiveDays = 90
+
+// AttritionOptions configures how an attrition report is produced
+type AttritionOptions struct {
+	// CompletedOnly ignores meetings which have not been completed
+	CompletedOnly bool
+	// InactiveDays is how long a beneficiary must go without a meeting to be considered inactive. Defaults to 90 days.
+	InactiveDays int
+	// AsOf is the time inactivity is measured up to. Defaults to now.
+	AsOf time.Time
+}
+
+func (o AttritionOptions) inactiveDays() int {
+	if o.InactiveDays == 0 {
+		return defaultInactiveDays
+	}
+	return o.InactiveDays
+}
+
+func (o AttritionOptions) asOf() time.Time {
+	if o.AsOf.IsZero() {
+		return time.Now()
+	}
+	return o.AsOf
+}
+
+func (o AttritionOptions) validate() error {
+	if o.InactiveDays < 0 {
+		return impact.ValidationErrors{{Field: "inactiveDays", Message: "Inactive days cannot be negative"}}
+	}
+	return nil
+}
+
+func (o AttritionOptions) isInactive(journey []impact.Meeting) bool {
+	last := journey[len(journey)-1]
+	return o.asOf().Sub(last.Conducted) > time.Duration(o.inactiveDays())*24*time.Hour
+}
+
+// medianDaysBetweenMeetings returns the median gap between consecutive meetings across all journeys, nil if there are no gaps
+func medianDaysBetweenMeetings(journeys map[string][]impact.Meeting) *float32 {
+	gaps := []float32{}
+	for _, journey := range journeys {
+		for i := 1; i < len(journey); i++ {
+			gaps = append(gaps, float32(journey[i].Conducted.Sub(journey[i-1].Conducted).Hours()/24))
+		}
+	}
+	if len(gaps) == 0 {
+		return nil
+	}
+	median := float32(quantile(sortedCopy(gaps), 0.5))
+	return &median
+}
+
+func getAttritionGroup(agg meetingAggregator, bens []string, journeys map[string][]impact.Meeting) impact.AttritionGroup {
+	baselines := make([]impact.Meeting, 0, len(bens))
+	for _, ben := range bens {
+		baselines = append(baselines, journeys[ben][0])
+	}
+	return impact.AttritionGroup{
+		BeneficiaryIDs:     bens,
+		QuestionAggregates: agg.getQuestionAggregates(baselines),
+		CategoryAggregates: agg.getCategoryAggregates(baselines),
+	}
+}
+
+// GetAttritionReport measures the engagement of the beneficiaries who had their first meeting for the outcome set within the time range.
+// Beneficiaries whose last meeting was longer ago than the inactivity period are considered to have dropped out,
+// their baseline meetings are aggregated separately to those of the beneficiaries who remain active.
+func GetAttritionReport(start, end time.Time, questionSetID string, opts AttritionOptions, db VisitDatabase, u auth.User) (*impact.AttritionReport, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	os, err := db.GetOutcomeSet(questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	meetingsInRange, err := db.GetOSMeetingsInTimeRange(start, end, questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	inRange := getJourneys(meetingsInRange, opts.CompletedOnly)
+	candidates := make([]string, 0, len(inRange))
+	for ben := range inRange {
+		candidates = append(candidates, ben)
+	}
+	sort.Strings(candidates)
+
+	journeys := map[string][]impact.Meeting{}
+	if len(candidates) > 0 {
+		meetings, err := db.GetOSMeetingsForBeneficiaries(candidates, questionSetID, u)
+		if err != nil {
+			return nil, err
+		}
+		journeys = getJourneys(meetings, opts.CompletedOnly)
+	}
+
+	// beneficiaries whose first meeting was before the time range started earlier, so are not part of the cohort
+	cohort := map[string][]impact.Meeting{}
+	dropped := []string{}
+	retained := []string{}
+	followedUp := 0
+	for _, ben := range candidates {
+		journey := journeys[ben]
+		if len(journey) == 0 || journey[0].Conducted.Before(start) {
+			continue
+		}
+		cohort[ben] = journey
+		if len(journey) > 1 {
+			followedUp++
+		}
+		if opts.isInactive(journey) {
+			dropped = append(dropped, ben)
+		} else {
+			retained = append(retained, ben)
+		}
+	}
+
+	agg := meetingAggregator{
+		report:        "AttritionReport",
+		questionSetID: questionSetID,
+		u:             u,
+		os:            os,
+	}
+	return &impact.AttritionReport{
+		QuestionSetID:             questionSetID,
+		Started:                   len(cohort),
+		FollowedUp:                followedUp,
+		FollowUpPercent:           percent(followedUp, len(cohort)),
+		MedianDaysBetweenMeetings: medianDaysBetweenMeetings(cohort),
+		InactiveDays:              opts.inactiveDays(),
+		Inactive:                  len(dropped),
+		InactivePercent:           percent(len(dropped), len(cohort)),
+		DroppedOut:                getAttritionGroup(agg, dropped, cohort),
+		Retained:                  getAttritionGroup(agg, retained, cohort),
+	}, nil
+}
@@ -0,0 +1,89 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/logic"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttritionReport(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	start := date(2017, time.January, 1)
+	end := date(2017, time.March, 1)
+	inRange := []impact.Meeting{
+		trendMeeting("B1M1", "B1", date(2017, time.January, 10), map[string]int{"Q1": 2}),
+		trendMeeting("B1M2", "B1", date(2017, time.February, 10), map[string]int{"Q1": 4}),
+		trendMeeting("B2M1", "B2", date(2017, time.January, 20), map[string]int{"Q1": 8}),
+		trendMeeting("B3M2", "B3", date(2017, time.January, 15), map[string]int{"Q1": 1}),
+		trendMeeting("B4M1", "B4", date(2017, time.February, 1), map[string]int{"Q1": 6}),
+		trendMeeting("B4M2", "B4", date(2017, time.February, 11), map[string]int{"Q1": 7}),
+	}
+	all := append([]impact.Meeting{
+		// B1 is still attending, B3 started before the time range
+		trendMeeting("B1M3", "B1", date(2017, time.May, 20), map[string]int{"Q1": 6}),
+		trendMeeting("B3M1", "B3", date(2016, time.December, 1), map[string]int{"Q1": 1}),
+	}, inRange...)
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRange, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiaries([]string{"B1", "B2", "B3", "B4"}, questionSetID, mockUser).Return(all, nil)
+
+		opts := logic.AttritionOptions{
+			InactiveDays: 60,
+			AsOf:         date(2017, time.June, 1),
+		}
+		report, err := logic.GetAttritionReport(start, end, questionSetID, opts, mockDB, mockUser)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, 3, report.Started)
+		assert.Equal(t, 2, report.FollowedUp)
+		assert.InDelta(t, 66.667, report.FollowUpPercent, 1e-3)
+		if assert.NotNil(t, report.MedianDaysBetweenMeetings) {
+			// gaps of 31, 99 and 10 days
+			assert.InDelta(t, 31, *report.MedianDaysBetweenMeetings, 1e-6)
+		}
+		assert.Equal(t, 60, report.InactiveDays)
+		assert.Equal(t, 2, report.Inactive)
+		assert.InDelta(t, 66.667, report.InactivePercent, 1e-3)
+
+		assert.Equal(t, []string{"B2", "B4"}, report.DroppedOut.BeneficiaryIDs)
+		if assert.Len(t, report.DroppedOut.QuestionAggregates, 1) {
+			assert.InDelta(t, 7, report.DroppedOut.QuestionAggregates[0].Value, 1e-6)
+		}
+		assert.Equal(t, []string{"B1"}, report.Retained.BeneficiaryIDs)
+		if assert.Len(t, report.Retained.CategoryAggregates, 1) {
+			assert.InDelta(t, 2, report.Retained.CategoryAggregates[0].Value, 1e-6)
+		}
+	})
+}
+
+func TestAttritionReportNoBeneficiaries(t *testing.T) {
+	end := time.Now()
+	start := end.AddDate(0, -1, 0)
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{}, nil)
+
+		report, err := logic.GetAttritionReport(start, end, questionSetID, logic.AttritionOptions{}, mockDB, mockUser)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, 0, report.Started)
+		assert.Equal(t, float32(0), report.FollowUpPercent)
+		assert.Nil(t, report.MedianDaysBetweenMeetings)
+		assert.Equal(t, 90, report.InactiveDays)
+		assert.Empty(t, report.DroppedOut.BeneficiaryIDs)
+		assert.Empty(t, report.Retained.QuestionAggregates)
+
+		_, err = logic.GetAttritionReport(start, end, questionSetID, logic.AttritionOptions{InactiveDays: -1}, mockDB, mockUser)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+	})
+}
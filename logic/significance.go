@@ -0,0 +1,287 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	impact "github.com/impactasaurus/server"
+)
+
+// smallSampleSize is the number of beneficiaries below which significance results are flagged as unreliable
+const smallSampleSize = 10
+
+// exactWilcoxonLimit is the number of non zero changes below which the Wilcoxon signed-rank p-value is calculated exactly.
+// Larger samples, or samples with tied or zero changes, use the normal approximation.
+const exactWilcoxonLimit = 50
+
+// logBeta returns the natural logarithm of the beta function
+func logBeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete beta function using the modified Lentz method
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+	qab, qap, qam := a+b, a+1, a-1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
+
+// regularizedIncompleteBeta returns I_x(a, b)
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	front := math.Exp(a*math.Log(x) + b*math.Log(1-x) - logBeta(a, b))
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// studentTCDF returns P(T <= t) for a Student's t distribution with df degrees of freedom
+func studentTCDF(t, df float64) float64 {
+	tail := 0.5 * regularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// studentTQuantile returns the value t such that P(T <= t) = p, found by bisection
+func studentTQuantile(p, df float64) float64 {
+	lo, hi := -1e3, 1e3
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		if studentTCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+func meanAndStdDev(in []float64) (float64, float64) {
+	var total float64
+	for _, v := range in {
+		total += v
+	}
+	return total / float64(len(in)), stdDev(in)
+}
+
+// pairedTTest returns the two sided p-value of a paired t-test on the changes, testing whether the mean change is zero.
+// The changes must have a non zero standard deviation and contain at least two values.
+func pairedTTest(changes []float64) float64 {
+	m, sd := meanAndStdDev(changes)
+	df := float64(len(changes) - 1)
+	t := m / (sd / math.Sqrt(float64(len(changes))))
+	return 2 * studentTCDF(-math.Abs(t), df)
+}
+
+// meanConfidenceInterval returns the 95% confidence interval for the mean change
+func meanConfidenceInterval(changes []float64) (float64, float64) {
+	m, sd := meanAndStdDev(changes)
+	n := float64(len(changes))
+	margin := studentTQuantile(0.975, n-1) * sd / math.Sqrt(n)
+	return m - margin, m + margin
+}
+
+// signedRanks ranks the absolute values of the non zero changes, averaging the ranks of ties.
+// The statistic V, the sum of the ranks of the positive changes, is returned along with the ranks and whether any ties were found.
+func signedRanks(changes []float64) (float64, []float64, bool) {
+	type change struct {
+		abs      float64
+		positive bool
+	}
+	nonZero := make([]change, 0, len(changes))
+	for _, c := range changes {
+		if c != 0 {
+			nonZero = append(nonZero, change{abs: math.Abs(c), positive: c > 0})
+		}
+	}
+	sort.Slice(nonZero, func(i, j int) bool {
+		return nonZero[i].abs < nonZero[j].abs
+	})
+	ranks := make([]float64, len(nonZero))
+	ties := false
+	for i := 0; i < len(nonZero); {
+		j := i
+		for j+1 < len(nonZero) && nonZero[j+1].abs == nonZero[i].abs {
+			j++
+		}
+		if j > i {
+			ties = true
+		}
+		avg := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranks[k] = avg
+		}
+		i = j + 1
+	}
+	var v float64
+	for i, c := range nonZero {
+		if c.positive {
+			v += ranks[i]
+		}
+	}
+	return v, ranks, ties
+}
+
+// signedRankDistribution returns the number of subsets of 1..n summing to each possible value of V
+func signedRankDistribution(n int) []float64 {
+	max := n * (n + 1) / 2
+	counts := make([]float64, max+1)
+	counts[0] = 1
+	for r := 1; r <= n; r++ {
+		for s := max; s >= r; s-- {
+			counts[s] += counts[s-r]
+		}
+	}
+	return counts
+}
+
+// wilcoxonSignedRank returns the two sided p-value of a Wilcoxon signed-rank test on the changes.
+// Zero changes are dropped. The boolean is false if there are no non zero changes to test.
+func wilcoxonSignedRank(changes []float64) (float64, bool) {
+	v, ranks, ties := signedRanks(changes)
+	n := len(ranks)
+	if n == 0 {
+		return 0, false
+	}
+	zeros := n != len(changes)
+	fn := float64(n)
+	if n < exactWilcoxonLimit && !ties && !zeros {
+		counts := signedRankDistribution(n)
+		total := math.Pow(2, fn)
+		var p float64
+		if v > fn*(fn+1)/4 {
+			for s := int(v); s < len(counts); s++ {
+				p += counts[s]
+			}
+		} else {
+			for s := 0; s <= int(v); s++ {
+				p += counts[s]
+			}
+		}
+		return math.Min(1, 2*p/total), true
+	}
+
+	z := v - fn*(fn+1)/4
+	var tieCorrection float64
+	for i := 0; i < n; {
+		j := i
+		for j+1 < n && ranks[j+1] == ranks[i] {
+			j++
+		}
+		t := float64(j - i + 1)
+		tieCorrection += t*t*t - t
+		i = j + 1
+	}
+	sigma := math.Sqrt(fn*(fn+1)*(2*fn+1)/24 - tieCorrection/48)
+	if sigma == 0 {
+		return 1, true
+	}
+	correction := 0.5
+	if z < 0 {
+		correction = -0.5
+	} else if z == 0 {
+		correction = 0
+	}
+	z = (z - correction) / sigma
+	return math.Min(1, 2*math.Min(normalCDF(z), normalCDF(-z))), true
+}
+
+func toFloat32Ptr(in float64) *float32 {
+	out := float32(in)
+	return &out
+}
+
+// getSignificance tests whether the changes between beneficiaries' first and last meetings are statistically meaningful.
+// Results which cannot be calculated for the provided changes are left nil and a warning explains why.
+func getSignificance(in []float32) *impact.DeltaSignificance {
+	changes := make([]float64, len(in))
+	for i, v := range in {
+		changes[i] = float64(v)
+	}
+	ret := &impact.DeltaSignificance{
+		Warnings: []string{},
+	}
+	n := len(changes)
+	if n < 2 {
+		ret.Warnings = append(ret.Warnings, "At least two beneficiaries are required to test the significance of the change")
+		return ret
+	}
+	if n < smallSampleSize {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("Only %d beneficiaries were included, significance results are unreliable for fewer than %d beneficiaries", n, smallSampleSize))
+	}
+
+	m, sd := meanAndStdDev(changes)
+	lower, upper := meanConfidenceInterval(changes)
+	ret.CILower = toFloat32Ptr(lower)
+	ret.CIUpper = toFloat32Ptr(upper)
+	if sd == 0 {
+		ret.Warnings = append(ret.Warnings, "Every beneficiary changed by the same amount, so the t-test and effect size cannot be calculated")
+	} else {
+		ret.PairedTTestP = toFloat32Ptr(pairedTTest(changes))
+		ret.CohensD = toFloat32Ptr(m / sd)
+	}
+
+	if p, ok := wilcoxonSignedRank(changes); ok {
+		ret.WilcoxonP = toFloat32Ptr(p)
+	} else {
+		ret.Warnings = append(ret.Warnings, "No beneficiary changed, so the Wilcoxon signed-rank test cannot be calculated")
+	}
+	return ret
+}
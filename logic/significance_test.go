@@ -0,0 +1,176 @@
+package logic_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/logic"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// getChangeReport produces a JOC report where each beneficiary answered Q1 with 0 in their first meeting
+// and with the provided change in their last meeting
+func getChangeReport(t *testing.T, changes []float64) *impact.JOCServiceReport {
+	return getFirstLastReport(t, make([]float64, len(changes)), changes, nil)
+}
+
+// getFirstLastReport produces a JOC report where each beneficiary answered Q1 with the provided values in their first and last meetings.
+// Q1 is the only question in category C1, which uses the provided change threshold.
+func getFirstLastReport(t *testing.T, firsts, lasts []float64, threshold *impact.ChangeThreshold) *impact.JOCServiceReport {
+	end := time.Unix(100000, 0)
+	start := end.Add(-time.Hour * 24)
+	os := impact.OutcomeSet{
+		ID: questionSetID,
+		Questions: []impact.Question{{
+			ID:         "Q1",
+			Type:       impact.NUMERIC,
+			CategoryID: "C1",
+		}},
+		Categories: []impact.Category{{
+			ID:              "C1",
+			Aggregation:     impact.MEAN,
+			ChangeThreshold: threshold,
+		}},
+	}
+	meeting := func(ben string, conducted time.Time, value float64) impact.Meeting {
+		return impact.Meeting{
+			ID:           fmt.Sprintf("%s-%d", ben, conducted.Unix()),
+			Beneficiary:  ben,
+			OutcomeSetID: questionSetID,
+			Conducted:    conducted,
+			Answers: []impact.Answer{{
+				QuestionID: "Q1",
+				Type:       impact.FLOAT,
+				Answer:     value,
+			}},
+		}
+	}
+
+	var result *impact.JOCServiceReport
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		inRange := make([]impact.Meeting, 0, len(lasts))
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(os, nil)
+		for i := range lasts {
+			ben := fmt.Sprintf("B%d", i)
+			first := meeting(ben, start.Add(-time.Hour), firsts[i])
+			last := meeting(ben, end, lasts[i])
+			inRange = append(inRange, last)
+			mockDB.EXPECT().GetOSMeetingsForBeneficiary(ben, questionSetID, mockUser).Return([]impact.Meeting{first, last}, nil)
+		}
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(inRange, nil)
+
+		var err error
+		result, err = logic.GetJOCServiceReport(start, end, questionSetID, logic.JOCOptions{}, mockDB, mockUser)
+		assert.NoError(t, err)
+	})
+	return result
+}
+
+func TestSignificanceSleepData(t *testing.T) {
+	// R's sleep dataset, reference values from t.test and wilcox.test with paired = TRUE
+	changes := []float64{1.2, 2.4, 1.3, 1.3, 0.0, 1.0, 1.8, 0.8, 4.6, 1.4}
+	result := getChangeReport(t, changes)
+
+	sig := result.QuestionAggregates.Delta[0].Significance
+	if assert.NotNil(t, sig) {
+		assert.InDelta(t, 0.002833, *sig.PairedTTestP, 1e-6)
+		assert.InDelta(t, 0.7001142, *sig.CILower, 1e-5)
+		assert.InDelta(t, 2.4598858, *sig.CIUpper, 1e-5)
+		assert.InDelta(t, 1.284558, *sig.CohensD, 1e-5)
+		assert.InDelta(t, 0.009091, *sig.WilcoxonP, 1e-6)
+		assert.Empty(t, sig.Warnings)
+	}
+	assert.Nil(t, result.QuestionAggregates.First[0].Significance)
+	assert.Nil(t, result.QuestionAggregates.Last[0].Significance)
+	assert.NotNil(t, result.CategoryAggregates.Delta[0].Significance)
+}
+
+func TestSignificanceExactWilcoxon(t *testing.T) {
+	// Hollander & Wolfe depression scores, from the examples of R's wilcox.test
+	changes := []float64{0.952, -0.147, 1.022, 0.43, 0.62, 0.59, 0.49, -0.08, 0.01}
+	result := getChangeReport(t, changes)
+
+	sig := result.QuestionAggregates.Delta[0].Significance
+	if assert.NotNil(t, sig) {
+		assert.InDelta(t, 0.0390625, *sig.WilcoxonP, 1e-7)
+		assert.Equal(t, []string{"Only 9 beneficiaries were included, significance results are unreliable for fewer than 10 beneficiaries"}, sig.Warnings)
+	}
+}
+
+func TestSignificanceSingleBeneficiary(t *testing.T) {
+	result := getChangeReport(t, []float64{2})
+
+	sig := result.QuestionAggregates.Delta[0].Significance
+	if assert.NotNil(t, sig) {
+		assert.Nil(t, sig.PairedTTestP)
+		assert.Nil(t, sig.WilcoxonP)
+		assert.Nil(t, sig.CohensD)
+		assert.Nil(t, sig.CILower)
+		assert.Nil(t, sig.CIUpper)
+		assert.Len(t, sig.Warnings, 1)
+	}
+}
+
+func TestSignificanceIdenticalChanges(t *testing.T) {
+	result := getChangeReport(t, []float64{0, 0, 0})
+
+	sig := result.QuestionAggregates.Delta[0].Significance
+	if assert.NotNil(t, sig) {
+		assert.Nil(t, sig.PairedTTestP)
+		assert.Nil(t, sig.CohensD)
+		assert.Nil(t, sig.WilcoxonP)
+		assert.Equal(t, float32(0), *sig.CILower)
+		assert.Equal(t, float32(0), *sig.CIUpper)
+		assert.Len(t, sig.Warnings, 3)
+	}
+}
+
+func TestChangeCounts(t *testing.T) {
+	result := getChangeReport(t, []float64{2, 0, -1.5, 0.5})
+
+	assert.Equal(t, &impact.ChangeCounts{
+		Improved:         2,
+		Unchanged:        1,
+		Declined:         1,
+		ImprovedPercent:  50,
+		UnchangedPercent: 25,
+		DeclinedPercent:  25,
+	}, result.QuestionAggregates.Delta[0].Change)
+	assert.Equal(t, result.QuestionAggregates.Delta[0].Change, result.CategoryAggregates.Delta[0].Change)
+	assert.Nil(t, result.QuestionAggregates.First[0].Change)
+	assert.Nil(t, result.CategoryAggregates.Last[0].Change)
+}
+
+func TestChangeCountsAbsoluteThreshold(t *testing.T) {
+	threshold := &impact.ChangeThreshold{Type: impact.ABSOLUTETHRESHOLD, Value: 1}
+	result := getFirstLastReport(t, []float64{0, 0, 0, 0}, []float64{2, 0, -1.5, 0.5}, threshold)
+
+	assert.Equal(t, &impact.ChangeCounts{
+		Threshold:        1,
+		Improved:         1,
+		Unchanged:        2,
+		Declined:         1,
+		ImprovedPercent:  25,
+		UnchangedPercent: 50,
+		DeclinedPercent:  25,
+	}, result.CategoryAggregates.Delta[0].Change)
+	// thresholds only apply to categories
+	assert.Equal(t, 2, result.QuestionAggregates.Delta[0].Change.Improved)
+}
+
+func TestChangeCountsRCIThreshold(t *testing.T) {
+	// first values have a standard deviation of 2.582, giving an RCI of 3.2006 with a reliability of 0.8
+	threshold := &impact.ChangeThreshold{Type: impact.RCITHRESHOLD, Value: 0.8}
+	result := getFirstLastReport(t, []float64{1, 3, 5, 7}, []float64{6, 3.5, 4, 7}, threshold)
+
+	change := result.CategoryAggregates.Delta[0].Change
+	if assert.NotNil(t, change) {
+		assert.InDelta(t, 3.2006, change.Threshold, 1e-4)
+		assert.Equal(t, 1, change.Improved)
+		assert.Equal(t, 3, change.Unchanged)
+		assert.Equal(t, 0, change.Declined)
+	}
+}
@@ -0,0 +1,191 @@
+package logic
+
+import (
+	"math"
+	"sort"
+
+	impact "github.com/impactasaurus/server"
+)
+
+// maxHistogramBins caps the number of bins used when summarising values as a histogram
+const maxHistogramBins = 10
+
+// distribution summarises the spread of a set of values
+type distribution struct {
+	count     int
+	median    float32
+	stdDev    float32
+	min       float32
+	max       float32
+	iqr       float32
+	histogram []impact.HistogramBin
+}
+
+func sortedCopy(in []float32) []float64 {
+	out := make([]float64, len(in))
+	for i, v := range in {
+		out[i] = float64(v)
+	}
+	sort.Float64s(out)
+	return out
+}
+
+// quantile returns the p quantile of the sorted values, interpolating linearly between the closest ranks
+func quantile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// stdDev returns the sample standard deviation of the values, 0 is returned if there are fewer than two values
+func stdDev(in []float64) float64 {
+	if len(in) < 2 {
+		return 0
+	}
+	var total float64
+	for _, v := range in {
+		total += v
+	}
+	m := total / float64(len(in))
+	var sq float64
+	for _, v := range in {
+		sq += (v - m) * (v - m)
+	}
+	return math.Sqrt(sq / float64(len(in)-1))
+}
+
+// histogram splits the range of the sorted values into equal width bins and counts the values in each.
+// The number of bins follows Sturges' rule, capped at maxHistogramBins. If all values are equal, a single bin is returned.
+func histogram(sorted []float64) []impact.HistogramBin {
+	if len(sorted) == 0 {
+		return []impact.HistogramBin{}
+	}
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		return []impact.HistogramBin{{Min: float32(lo), Max: float32(hi), Count: len(sorted)}}
+	}
+	noBins := int(math.Ceil(math.Log2(float64(len(sorted))))) + 1
+	if noBins > maxHistogramBins {
+		noBins = maxHistogramBins
+	}
+	width := (hi - lo) / float64(noBins)
+	bins := make([]impact.HistogramBin, noBins)
+	for i := range bins {
+		bins[i].Min = float32(lo + width*float64(i))
+		bins[i].Max = float32(lo + width*float64(i+1))
+	}
+	bins[noBins-1].Max = float32(hi)
+	for _, v := range sorted {
+		idx := int((v - lo) / width)
+		if idx >= noBins {
+			idx = noBins - 1
+		}
+		bins[idx].Count++
+	}
+	return bins
+}
+
+func describe(in []float32) distribution {
+	sorted := sortedCopy(in)
+	if len(sorted) == 0 {
+		return distribution{histogram: []impact.HistogramBin{}}
+	}
+	return distribution{
+		count:     len(sorted),
+		median:    float32(quantile(sorted, 0.5)),
+		stdDev:    float32(stdDev(sorted)),
+		min:       float32(sorted[0]),
+		max:       float32(sorted[len(sorted)-1]),
+		iqr:       float32(quantile(sorted, 0.75) - quantile(sorted, 0.25)),
+		histogram: histogram(sorted),
+	}
+}
+
+// rciCriticalValue is the z score used when calculating a reliable change index
+const rciCriticalValue = 1.96
+
+// changeThreshold returns the change a beneficiary's value must exceed to count as an improvement or decline.
+// Without a threshold, any change counts. RCI thresholds are derived from the spread of the first meeting values.
+func changeThreshold(threshold *impact.ChangeThreshold, first []float32) float32 {
+	if threshold == nil {
+		return 0
+	}
+	switch threshold.Type {
+	case impact.ABSOLUTETHRESHOLD:
+		return threshold.Value
+	case impact.RCITHRESHOLD:
+		sd := stdDev(sortedCopy(first))
+		return float32(rciCriticalValue * math.Sqrt2 * sd * math.Sqrt(1-float64(threshold.Value)))
+	default:
+		return 0
+	}
+}
+
+// countChanges counts the changes above, within and below the threshold
+func countChanges(changes []float32, threshold float32) *impact.ChangeCounts {
+	ret := &impact.ChangeCounts{
+		Threshold: threshold,
+	}
+	for _, c := range changes {
+		switch {
+		case c > threshold:
+			ret.Improved++
+		case c < -threshold:
+			ret.Declined++
+		default:
+			ret.Unchanged++
+		}
+	}
+	ret.ImprovedPercent = percent(ret.Improved, len(changes))
+	ret.UnchangedPercent = percent(ret.Unchanged, len(changes))
+	ret.DeclinedPercent = percent(ret.Declined, len(changes))
+	return ret
+}
+
+// newQBenAgg summarises the beneficiaries' values for a question with their mean and distribution
+func newQBenAgg(questionID string, values []float32, benIDs, warnings []string) impact.QBenAgg {
+	d := describe(values)
+	return impact.QBenAgg{
+		QuestionID:     questionID,
+		Warnings:       warnings,
+		BeneficiaryIDs: benIDs,
+		Value:          mean(values),
+		Count:          d.count,
+		Median:         d.median,
+		StdDev:         d.stdDev,
+		Min:            d.min,
+		Max:            d.max,
+		IQR:            d.iqr,
+		Histogram:      d.histogram,
+	}
+}
+
+// newCatBenAgg summarises the beneficiaries' values for a category with their mean and distribution
+func newCatBenAgg(categoryID string, values []float32, benIDs, warnings []string) impact.CatBenAgg {
+	d := describe(values)
+	return impact.CatBenAgg{
+		CategoryID:     categoryID,
+		Warnings:       warnings,
+		BeneficiaryIDs: benIDs,
+		Value:          mean(values),
+		Count:          d.count,
+		Median:         d.median,
+		StdDev:         d.stdDev,
+		Min:            d.min,
+		Max:            d.max,
+		IQR:            d.iqr,
+		Histogram:      d.histogram,
+	}
+}
+
+// percent returns count as a percentage of total, between 0 and 100. Zero is returned if total is zero.
+func percent(count, total int) float32 {
+	if total == 0 {
+		return 0
+	}
+	return float32(count) * 100 / float32(total)
+}
@@ -0,0 +1,168 @@
+package logic
+
+import (
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+	"github.com/impactasaurus/server/data"
+)
+
+type SubjectAccessDatabase interface {
+	GetOrganisation(id string, u auth.User) (impact.Organisation, error)
+	GetBeneficiary(id string, u auth.User) (impact.Beneficiary, error)
+	GetBeneficiaryChanges(beneficiaryID string, u auth.User) ([]impact.BeneficiaryChange, error)
+	GetMeetings(filter data.MeetingFilter, page data.MeetingPageRequest, u auth.User) (data.MeetingPage, error)
+	GetOutcomeSet(id string, u auth.User) (impact.OutcomeSet, error)
+}
+
+// getAllBeneficiaryMeetings pages through all of the beneficiary's meetings, including deleted meetings
+func getAllBeneficiaryMeetings(beneficiaryID string, db SubjectAccessDatabase, u auth.User) ([]impact.Meeting, error) {
+	out := []impact.Meeting{}
+	page := data.MeetingPageRequest{
+		First:     data.MaxMeetingPageSize,
+		SortBy:    data.SortByConducted,
+		Ascending: true,
+	}
+	filter := data.MeetingFilter{
+		Beneficiary:    beneficiaryID,
+		IncludeDeleted: true,
+	}
+	for {
+		res, err := db.GetMeetings(filter, page, u)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range res.Edges {
+			out = append(out, e.Meeting)
+		}
+		if !res.HasNextPage {
+			return out, nil
+		}
+		page.After = res.EndCursor()
+	}
+}
+
+// subjectAccessAnswer resolves the answer's question. Choice answers are converted to the labels of the selected choices.
+func subjectAccessAnswer(a impact.Answer, os *impact.OutcomeSet) impact.SubjectAccessAnswer {
+	out := impact.SubjectAccessAnswer{
+		QuestionID: a.QuestionID,
+		Answer:     a.Answer,
+	}
+	if os == nil {
+		return out
+	}
+	q := os.GetQuestion(a.QuestionID)
+	if q == nil {
+		return out
+	}
+	out.Question = q.Question
+	out.Description = q.Description
+	if a.Type == impact.CHOICE || a.Type == impact.CHOICES {
+		ids, err := a.ChoiceIDs()
+		if err != nil {
+			return out
+		}
+		labels := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if c := q.GetChoice(id); c != nil {
+				labels = append(labels, c.Label)
+			} else {
+				labels = append(labels, id)
+			}
+		}
+		if a.Type == impact.CHOICE && len(labels) == 1 {
+			out.Answer = labels[0]
+		} else {
+			out.Answer = labels
+		}
+	}
+	return out
+}
+
+// GetSubjectAccessExport gathers all of the data held about a beneficiary, including their deleted meetings,
+// with outcome sets, questions and profile fields resolved so the export can be understood on its own.
+func GetSubjectAccessExport(beneficiaryID string, db SubjectAccessDatabase, u auth.User) (*impact.SubjectAccessExport, error) {
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+	org, err := db.GetOrganisation(userOrg, u)
+	if err != nil {
+		return nil, err
+	}
+	var ben *impact.Beneficiary
+	b, err := db.GetBeneficiary(beneficiaryID, u)
+	if err == nil {
+		ben = &b
+	} else if !data.IsNotFound(err) {
+		return nil, err
+	}
+	meetings, err := getAllBeneficiaryMeetings(beneficiaryID, db, u)
+	if err != nil {
+		return nil, err
+	}
+	if ben == nil && len(meetings) == 0 {
+		return nil, data.NewNotFoundError("Beneficiary")
+	}
+	changes, err := db.GetBeneficiaryChanges(beneficiaryID, u)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := impact.SubjectAccessExport{
+		BeneficiaryID:  beneficiaryID,
+		OrganisationID: userOrg,
+		Generated:      time.Now(),
+		Tags:           []string{},
+		Profile:        []impact.SubjectAccessProfileValue{},
+		Meetings:       make([]impact.SubjectAccessMeeting, 0, len(meetings)),
+		Changes:        changes,
+	}
+	if ben != nil {
+		if ben.Tags != nil {
+			ret.Tags = ben.Tags
+		}
+		for _, f := range org.ProfileFields {
+			if v, ok := ben.Profile[f.ID]; ok {
+				ret.Profile = append(ret.Profile, impact.SubjectAccessProfileValue{
+					FieldID: f.ID,
+					Field:   f.Name,
+					Value:   v,
+				})
+			}
+		}
+	}
+
+	outcomeSets := map[string]*impact.OutcomeSet{}
+	for _, m := range meetings {
+		os, fetched := outcomeSets[m.OutcomeSetID]
+		if !fetched {
+			o, err := db.GetOutcomeSet(m.OutcomeSetID, u)
+			if err == nil {
+				os = &o
+			} else if !data.IsNotFound(err) {
+				return nil, err
+			}
+			outcomeSets[m.OutcomeSetID] = os
+		}
+		sam := impact.SubjectAccessMeeting{
+			ID:           m.ID,
+			OutcomeSetID: m.OutcomeSetID,
+			Conducted:    m.Conducted,
+			Created:      m.Created,
+			Modified:     m.Modified,
+			Status:       m.GetStatus(),
+			Deleted:      m.Deleted,
+			Answers:      make([]impact.SubjectAccessAnswer, 0, len(m.Answers)),
+		}
+		if os != nil {
+			sam.OutcomeSet = os.Name
+		}
+		for _, a := range m.Answers {
+			sam.Answers = append(sam.Answers, subjectAccessAnswer(a, os))
+		}
+		ret.Meetings = append(ret.Meetings, sam)
+	}
+	return &ret, nil
+}
@@ -0,0 +1,132 @@
+package logic_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/data"
+	"github.com/impactasaurus/server/logic"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubjectAccessExport(t *testing.T) {
+	org := impact.Organisation{
+		ID: "org",
+		ProfileFields: []impact.ProfileField{{
+			ID:   "postcode",
+			Name: "Postcode",
+			Type: impact.TEXTFIELD,
+		}},
+	}
+	os := impact.OutcomeSet{
+		ID:   "os",
+		Name: "Wellbeing",
+		Questions: []impact.Question{{
+			ID:       "Q1",
+			Question: "How are you?",
+			Type:     impact.LIKERT,
+		}, {
+			ID:       "Q2",
+			Question: "Which groups do you attend?",
+			Type:     impact.MULTICHOICE,
+			Choices:  []impact.Choice{{ID: "a", Label: "Art"}, {ID: "b", Label: "Boxing"}},
+		}},
+	}
+	conducted := time.Unix(10000, 0)
+	page1 := data.MeetingPage{
+		Edges: []data.MeetingEdge{{
+			Cursor: "c1",
+			Meeting: impact.Meeting{
+				ID:           "M1",
+				Beneficiary:  "B1",
+				OutcomeSetID: "os",
+				Conducted:    conducted,
+				Answers: []impact.Answer{
+					{QuestionID: "Q1", Type: impact.INT, Answer: 3},
+					{QuestionID: "Q2", Type: impact.CHOICES, Answer: []string{"a", "b"}},
+				},
+			},
+		}},
+		HasNextPage: true,
+	}
+	page2 := data.MeetingPage{
+		Edges: []data.MeetingEdge{{
+			Cursor: "c2",
+			Meeting: impact.Meeting{
+				ID:           "M2",
+				Beneficiary:  "B1",
+				OutcomeSetID: "removed",
+				Deleted:      true,
+				Answers:      []impact.Answer{{QuestionID: "Q9", Type: impact.INT, Answer: 1}},
+			},
+		}},
+	}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		filter := data.MeetingFilter{Beneficiary: "B1", IncludeDeleted: true}
+		firstPage := data.MeetingPageRequest{First: data.MaxMeetingPageSize, SortBy: data.SortByConducted, Ascending: true}
+		secondPage := firstPage
+		secondPage.After = "c1"
+
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(org, nil)
+		mockDB.EXPECT().GetBeneficiary("B1", mockUser).Return(impact.Beneficiary{
+			ID:      "B1",
+			Tags:    []string{"youth"},
+			Profile: map[string]interface{}{"postcode": "SW1"},
+		}, nil)
+		mockDB.EXPECT().GetMeetings(filter, firstPage, mockUser).Return(page1, nil)
+		mockDB.EXPECT().GetMeetings(filter, secondPage, mockUser).Return(page2, nil)
+		mockDB.EXPECT().GetBeneficiaryChanges("B1", mockUser).Return([]impact.BeneficiaryChange{}, nil)
+		mockDB.EXPECT().GetOutcomeSet("os", mockUser).Return(os, nil)
+		mockDB.EXPECT().GetOutcomeSet("removed", mockUser).Return(impact.OutcomeSet{}, data.NewNotFoundError("OutcomeSet"))
+
+		export, err := logic.GetSubjectAccessExport("B1", mockDB, mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, "B1", export.BeneficiaryID)
+		assert.Equal(t, []string{"youth"}, export.Tags)
+		assert.Equal(t, []impact.SubjectAccessProfileValue{{FieldID: "postcode", Field: "Postcode", Value: "SW1"}}, export.Profile)
+		if assert.Len(t, export.Meetings, 2) {
+			m1 := export.Meetings[0]
+			assert.Equal(t, "Wellbeing", m1.OutcomeSet)
+			assert.Equal(t, impact.COMPLETE, m1.Status)
+			assert.Equal(t, impact.SubjectAccessAnswer{QuestionID: "Q1", Question: "How are you?", Answer: 3}, m1.Answers[0])
+			assert.Equal(t, []string{"Art", "Boxing"}, m1.Answers[1].Answer)
+			m2 := export.Meetings[1]
+			assert.True(t, m2.Deleted)
+			assert.Equal(t, "", m2.OutcomeSet)
+			assert.Equal(t, impact.SubjectAccessAnswer{QuestionID: "Q9", Answer: 1}, m2.Answers[0])
+		}
+	})
+}
+
+func TestSubjectAccessExportUnknownBeneficiary(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(impact.Organisation{ID: "org"}, nil)
+		mockDB.EXPECT().GetBeneficiary("B9", mockUser).Return(impact.Beneficiary{}, data.NewNotFoundError("Beneficiary"))
+		mockDB.EXPECT().GetMeetings(gomock.Any(), gomock.Any(), mockUser).Return(data.MeetingPage{}, nil)
+
+		export, err := logic.GetSubjectAccessExport("B9", mockDB, mockUser)
+		assert.Nil(t, export)
+		assert.True(t, data.IsNotFound(err))
+	})
+}
+
+func TestSubjectAccessExportMeetingError(t *testing.T) {
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		e := errors.New("Mongo error")
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(impact.Organisation{ID: "org"}, nil)
+		mockDB.EXPECT().GetBeneficiary("B1", mockUser).Return(impact.Beneficiary{ID: "B1"}, nil)
+		mockDB.EXPECT().GetMeetings(gomock.Any(), gomock.Any(), mockUser).Return(data.MeetingPage{}, e)
+
+		export, err := logic.GetSubjectAccessExport("B1", mockDB, mockUser)
+		assert.Nil(t, export)
+		assert.EqualError(t, err, e.Error())
+	})
+}
@@ -0,0 +1,226 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+)
+
+// maxTrendBuckets limits the size of trend reports, 10 years of weeks
+const maxTrendBuckets = 520
+
+type TrendDatabase interface {
+	GetOutcomeSet(id string, u auth.User) (impact.OutcomeSet, error)
+	GetOSMeetingsInTimeRange(start, end time.Time, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
+	GetOrganisation(id string, u auth.User) (impact.Organisation, error)
+}
+
+// TrendOptions configures how a trend report is produced
+type TrendOptions struct {
+	Period impact.TrendPeriod
+	// FinancialYearStart is the month, 1 to 12, in which financial years start. Defaults to April.
+	FinancialYearStart int
+	// CompletedOnly restricts the report to meetings which have been completed
+	CompletedOnly bool
+}
+
+func (o TrendOptions) financialYearStart() int {
+	if o.FinancialYearStart == 0 {
+		return int(time.April)
+	}
+	return o.FinancialYearStart
+}
+
+func (o TrendOptions) validate() error {
+	switch o.Period {
+	case impact.WEEK, impact.MONTH, impact.QUARTER, impact.FINANCIALYEAR:
+	default:
+		return impact.ValidationErrors{{Field: "period", Message: fmt.Sprintf("Unknown trend period %s", o.Period)}}
+	}
+	if fy := o.financialYearStart(); fy < 1 || fy > 12 {
+		return impact.ValidationErrors{{Field: "financialYearStart", Message: "Financial year start must be a month between 1 and 12"}}
+	}
+	return nil
+}
+
+// periodStart returns the start of the period containing t, in t's location.
+// Weeks start on Monday.
+func (o TrendOptions) periodStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch o.Period {
+	case impact.WEEK:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-daysSinceMonday, 0, 0, 0, 0, t.Location())
+	case impact.QUARTER:
+		return time.Date(y, ((m-1)/3)*3+1, 1, 0, 0, 0, 0, t.Location())
+	case impact.FINANCIALYEAR:
+		fyStart := time.Month(o.financialYearStart())
+		if m < fyStart {
+			y--
+		}
+		return time.Date(y, fyStart, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func (o TrendOptions) nextPeriod(start time.Time) time.Time {
+	switch o.Period {
+	case impact.WEEK:
+		return start.AddDate(0, 0, 7)
+	case impact.QUARTER:
+		return start.AddDate(0, 3, 0)
+	case impact.FINANCIALYEAR:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// periodLabel names the period, for example 2017-W03, 2017-01, 2017-Q1 or 2017/18.
+// Financial years starting in January are labelled with their year alone.
+func (o TrendOptions) periodLabel(start time.Time) string {
+	switch o.Period {
+	case impact.WEEK:
+		y, w := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	case impact.QUARTER:
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	case impact.FINANCIALYEAR:
+		if start.Month() == time.January {
+			return fmt.Sprintf("%d", start.Year())
+		}
+		return fmt.Sprintf("%d/%02d", start.Year(), (start.Year()+1)%100)
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+// getBuckets returns every period overlapping the time range, including periods without meetings
+func (o TrendOptions) getBuckets(start, end time.Time, loc *time.Location) ([]impact.TrendBucket, error) {
+	buckets := []impact.TrendBucket{}
+	for s := o.periodStart(start.In(loc)); s.Before(end); s = o.nextPeriod(s) {
+		if len(buckets) == maxTrendBuckets {
+			return nil, impact.ValidationErrors{{Field: "period", Message: fmt.Sprintf("The time range covers more than %d periods, please use a longer period or a shorter time range", maxTrendBuckets)}}
+		}
+		e := o.nextPeriod(s)
+		buckets = append(buckets, impact.TrendBucket{
+			Start:              s,
+			End:                e,
+			Label:              o.periodLabel(s),
+			QuestionAggregates: []impact.QBenAgg{},
+			CategoryAggregates: []impact.CatBenAgg{},
+		})
+	}
+	return buckets, nil
+}
+
+type trendReporter struct {
+	agg      meetingAggregator
+	warnings []string
+}
+
+func (t *trendReporter) addWarning(warning string) {
+	t.warnings = append(t.warnings, warning)
+}
+
+// getLatestMeetings returns each beneficiary's latest meeting, sorted by beneficiary ID
+func getLatestMeetings(meetings []impact.Meeting) []impact.Meeting {
+	latest := map[string]impact.Meeting{}
+	for _, m := range meetings {
+		existing, exists := latest[m.Beneficiary]
+		if !exists || existing.Conducted.Before(m.Conducted) {
+			latest[m.Beneficiary] = m
+		}
+	}
+	out := make([]impact.Meeting, 0, len(latest))
+	for _, m := range latest {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Beneficiary < out[j].Beneficiary
+	})
+	return out
+}
+
+// GetTrendReport groups the outcome set's meetings within the time range by calendar period and aggregates each period.
+// Periods are calculated in the organisation's time zone.
+func GetTrendReport(start, end time.Time, questionSetID string, opts TrendOptions, db TrendDatabase, u auth.User) (*impact.TrendReport, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if !start.Before(end) {
+		return nil, impact.ValidationErrors{{Field: "end", Message: "End must be after start"}}
+	}
+	userOrg, err := u.Organisation()
+	if err != nil {
+		return nil, err
+	}
+	org, err := db.GetOrganisation(userOrg, u)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := org.Location()
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := opts.getBuckets(start, end, loc)
+	if err != nil {
+		return nil, err
+	}
+	os, err := db.GetOutcomeSet(questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	meetings, err := db.GetOSMeetingsInTimeRange(start, end, questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+
+	t := trendReporter{
+		agg: meetingAggregator{
+			report:        "TrendReport",
+			questionSetID: questionSetID,
+			u:             u,
+			os:            os,
+		},
+		warnings: []string{},
+	}
+
+	bucketIdx := make(map[int64]int, len(buckets))
+	for i, b := range buckets {
+		bucketIdx[b.Start.Unix()] = i
+	}
+	bucketed := make([][]impact.Meeting, len(buckets))
+	for _, m := range meetings {
+		if opts.CompletedOnly && !m.IsComplete() {
+			continue
+		}
+		idx, ok := bucketIdx[opts.periodStart(m.Conducted.In(loc)).Unix()]
+		if !ok {
+			t.addWarning(fmt.Sprintf("Meeting %s not included as it was conducted outside of the report's time range", m.ID))
+			continue
+		}
+		bucketed[idx] = append(bucketed[idx], m)
+	}
+
+	for i := range buckets {
+		latest := getLatestMeetings(bucketed[i])
+		buckets[i].MeetingCount = len(bucketed[i])
+		buckets[i].BeneficiaryCount = len(latest)
+		buckets[i].QuestionAggregates = t.agg.getQuestionAggregates(latest)
+		buckets[i].CategoryAggregates = t.agg.getCategoryAggregates(latest)
+	}
+
+	return &impact.TrendReport{
+		QuestionSetID:      questionSetID,
+		Period:             opts.Period,
+		FinancialYearStart: opts.financialYearStart(),
+		TimeZone:           loc.String(),
+		Buckets:            buckets,
+		Warnings:           t.warnings,
+	}, nil
+}
@@ -0,0 +1,215 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/logic"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func trendMeeting(id, ben string, conducted time.Time, answers map[string]int) impact.Meeting {
+	m := impact.Meeting{
+		ID:           id,
+		Beneficiary:  ben,
+		OutcomeSetID: questionSetID,
+		Conducted:    conducted,
+		Answers:      []impact.Answer{},
+	}
+	for _, q := range []string{"Q1", "Q2", "Q3", "Q4"} {
+		if v, ok := answers[q]; ok {
+			m.Answers = append(m.Answers, impact.Answer{QuestionID: q, Type: impact.INT, Answer: v})
+		}
+	}
+	return m
+}
+
+func getTrendReport(t *testing.T, start, end time.Time, opts logic.TrendOptions, org impact.Organisation, meetings []impact.Meeting) *impact.TrendReport {
+	var report *impact.TrendReport
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(org, nil)
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(meetings, nil)
+
+		var err error
+		report, err = logic.GetTrendReport(start, end, questionSetID, opts, mockDB, mockUser)
+		if !assert.Nil(t, err) {
+			t.FailNow()
+		}
+	})
+	return report
+}
+
+func getBucketLabels(r *impact.TrendReport) []string {
+	labels := make([]string, 0, len(r.Buckets))
+	for _, b := range r.Buckets {
+		labels = append(labels, b.Label)
+	}
+	return labels
+}
+
+func TestTrendReportMonthly(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2017, month, day, 12, 0, 0, 0, time.UTC)
+	}
+	start := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	meetings := []impact.Meeting{
+		trendMeeting("B1M1", "B1", date(time.January, 5), map[string]int{"Q1": 2, "Q2": 4}),
+		trendMeeting("B1M2", "B1", date(time.January, 20), map[string]int{"Q1": 4, "Q2": 4}),
+		trendMeeting("B2M1", "B2", date(time.January, 10), map[string]int{"Q1": 6}),
+		trendMeeting("B1M3", "B1", date(time.March, 3), map[string]int{"Q1": 8, "Q3": 1, "Q4": 3}),
+	}
+
+	report := getTrendReport(t, start, end, logic.TrendOptions{Period: impact.MONTH}, impact.Organisation{ID: "org"}, meetings)
+
+	assert.Equal(t, impact.MONTH, report.Period)
+	assert.Equal(t, "UTC", report.TimeZone)
+	assert.Empty(t, report.Warnings)
+	assert.Equal(t, []string{"2017-01", "2017-02", "2017-03"}, getBucketLabels(report))
+
+	jan := report.Buckets[0]
+	assert.Equal(t, start, jan.Start)
+	assert.Equal(t, time.Date(2017, time.February, 1, 0, 0, 0, 0, time.UTC), jan.End)
+	assert.Equal(t, 3, jan.MeetingCount)
+	assert.Equal(t, 2, jan.BeneficiaryCount)
+	if assert.Len(t, jan.QuestionAggregates, 2) {
+		// B1 is represented by their latest meeting in January
+		assert.Equal(t, "Q1", jan.QuestionAggregates[0].QuestionID)
+		assert.InDelta(t, 5, jan.QuestionAggregates[0].Value, 1e-6)
+		assert.Equal(t, []string{"B1", "B2"}, jan.QuestionAggregates[0].BeneficiaryIDs)
+		assert.Equal(t, "Q2", jan.QuestionAggregates[1].QuestionID)
+		assert.InDelta(t, 4, jan.QuestionAggregates[1].Value, 1e-6)
+		assert.Equal(t, []string{"B1"}, jan.QuestionAggregates[1].BeneficiaryIDs)
+	}
+	if assert.Len(t, jan.CategoryAggregates, 1) {
+		assert.Equal(t, "C1", jan.CategoryAggregates[0].CategoryID)
+		assert.InDelta(t, 5, jan.CategoryAggregates[0].Value, 1e-6)
+		assert.Equal(t, 2, jan.CategoryAggregates[0].Count)
+	}
+
+	feb := report.Buckets[1]
+	assert.Equal(t, 0, feb.MeetingCount)
+	assert.Equal(t, 0, feb.BeneficiaryCount)
+	assert.Empty(t, feb.QuestionAggregates)
+	assert.Empty(t, feb.CategoryAggregates)
+
+	mar := report.Buckets[2]
+	assert.Equal(t, 1, mar.MeetingCount)
+	assert.Equal(t, 1, mar.BeneficiaryCount)
+	assert.Len(t, mar.QuestionAggregates, 3)
+	if assert.Len(t, mar.CategoryAggregates, 2) {
+		assert.Equal(t, "C2", mar.CategoryAggregates[1].CategoryID)
+		assert.InDelta(t, 2, mar.CategoryAggregates[1].Value, 1e-6)
+	}
+}
+
+func TestTrendReportCompletedOnly(t *testing.T) {
+	start := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, time.February, 1, 0, 0, 0, 0, time.UTC)
+	incomplete := trendMeeting("B1M2", "B1", start.Add(time.Hour*48), map[string]int{"Q1": 1})
+	incomplete.Status = impact.INPROGRESS
+	meetings := []impact.Meeting{
+		trendMeeting("B1M1", "B1", start.Add(time.Hour*24), map[string]int{"Q1": 3}),
+		incomplete,
+	}
+
+	report := getTrendReport(t, start, end, logic.TrendOptions{Period: impact.MONTH, CompletedOnly: true}, impact.Organisation{ID: "org"}, meetings)
+
+	if assert.Len(t, report.Buckets, 1) {
+		assert.Equal(t, 1, report.Buckets[0].MeetingCount)
+		assert.InDelta(t, 3, report.Buckets[0].QuestionAggregates[0].Value, 1e-6)
+	}
+}
+
+func TestTrendReportTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.Nil(t, err) {
+		return
+	}
+	start := time.Date(2017, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2017, time.March, 1, 0, 0, 0, 0, loc)
+	// 31st January in New York, 1st February in UTC
+	meetings := []impact.Meeting{
+		trendMeeting("B1M1", "B1", time.Date(2017, time.February, 1, 3, 0, 0, 0, time.UTC), map[string]int{"Q1": 3}),
+	}
+
+	org := impact.Organisation{ID: "org", TimeZone: "America/New_York"}
+	report := getTrendReport(t, start, end, logic.TrendOptions{Period: impact.MONTH}, org, meetings)
+
+	assert.Equal(t, "America/New_York", report.TimeZone)
+	assert.Equal(t, []string{"2017-01", "2017-02"}, getBucketLabels(report))
+	assert.True(t, start.Equal(report.Buckets[0].Start))
+	assert.Equal(t, 1, report.Buckets[0].MeetingCount)
+	assert.Equal(t, 0, report.Buckets[1].MeetingCount)
+}
+
+func TestTrendReportPeriodLabels(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		opts     logic.TrendOptions
+		expected []string
+	}{{
+		name:     "weeks start on monday",
+		start:    date(2017, time.January, 1),
+		end:      date(2017, time.January, 10),
+		opts:     logic.TrendOptions{Period: impact.WEEK},
+		expected: []string{"2016-W52", "2017-W01", "2017-W02"},
+	}, {
+		name:     "quarters",
+		start:    date(2017, time.February, 1),
+		end:      date(2017, time.August, 1),
+		opts:     logic.TrendOptions{Period: impact.QUARTER},
+		expected: []string{"2017-Q1", "2017-Q2", "2017-Q3"},
+	}, {
+		name:     "financial years default to april",
+		start:    date(2017, time.January, 1),
+		end:      date(2018, time.June, 1),
+		opts:     logic.TrendOptions{Period: impact.FINANCIALYEAR},
+		expected: []string{"2016/17", "2017/18", "2018/19"},
+	}, {
+		name:     "financial years starting in january",
+		start:    date(2017, time.March, 1),
+		end:      date(2018, time.June, 1),
+		opts:     logic.TrendOptions{Period: impact.FINANCIALYEAR, FinancialYearStart: 1},
+		expected: []string{"2017", "2018"},
+	}, {
+		name:     "financial years starting in july",
+		start:    date(2017, time.July, 1),
+		end:      date(2018, time.July, 1),
+		opts:     logic.TrendOptions{Period: impact.FINANCIALYEAR, FinancialYearStart: 7},
+		expected: []string{"2017/18"},
+	}}
+	for _, test := range tests {
+		report := getTrendReport(t, test.start, test.end, test.opts, impact.Organisation{ID: "org"}, []impact.Meeting{})
+		assert.Equal(t, test.expected, getBucketLabels(report), test.name)
+	}
+}
+
+func TestTrendReportValidation(t *testing.T) {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		_, err := logic.GetTrendReport(start, end, questionSetID, logic.TrendOptions{Period: "fortnight"}, mockDB, mockUser)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+
+		_, err = logic.GetTrendReport(start, end, questionSetID, logic.TrendOptions{Period: impact.FINANCIALYEAR, FinancialYearStart: 13}, mockDB, mockUser)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+
+		_, err = logic.GetTrendReport(end, start, questionSetID, logic.TrendOptions{Period: impact.MONTH}, mockDB, mockUser)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+
+		mockUser.EXPECT().Organisation().Return("org", nil)
+		mockDB.EXPECT().GetOrganisation("org", mockUser).Return(impact.Organisation{ID: "org"}, nil)
+		_, err = logic.GetTrendReport(start, end, questionSetID, logic.TrendOptions{Period: impact.WEEK}, mockDB, mockUser)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+	})
+}
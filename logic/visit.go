@@ -0,0 +1,117 @@
+package logic
+
+import (
+	"sort"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/auth"
+)
+
+type VisitDatabase interface {
+	GetOutcomeSet(id string, u auth.User) (impact.OutcomeSet, error)
+	GetOSMeetingsInTimeRange(start, end time.Time, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
+	GetOSMeetingsForBeneficiaries(beneficiaries []string, outcomeSetID string, u auth.User) ([]impact.Meeting, error)
+}
+
+// VisitOptions configures how a visit report is produced
+type VisitOptions struct {
+	// CompletedOnly ignores meetings which have not been completed, they do not count as a visit
+	CompletedOnly bool
+	// MaxVisits limits the number of steps in the report, zero includes every visit
+	MaxVisits int
+}
+
+func (o VisitOptions) validate() error {
+	if o.MaxVisits < 0 {
+		return impact.ValidationErrors{{Field: "maxVisits", Message: "Max visits cannot be negative"}}
+	}
+	return nil
+}
+
+// getJourneys groups the meetings by beneficiary, ordering each beneficiary's meetings by when they were conducted
+func getJourneys(meetings []impact.Meeting, completedOnly bool) map[string][]impact.Meeting {
+	journeys := map[string][]impact.Meeting{}
+	for _, m := range meetings {
+		if !completedOnly || m.IsComplete() {
+			journeys[m.Beneficiary] = append(journeys[m.Beneficiary], m)
+		}
+	}
+	for _, journey := range journeys {
+		sort.SliceStable(journey, func(i, j int) bool {
+			return journey[i].Conducted.Before(journey[j].Conducted)
+		})
+	}
+	return journeys
+}
+
+// GetVisitReport aggregates the journeys of the beneficiaries with meetings within the time range by meeting number.
+// Each beneficiary's meetings are ordered, including those outside of the time range, and their first meetings are aggregated together,
+// followed by their second meetings and so on.
+func GetVisitReport(start, end time.Time, questionSetID string, opts VisitOptions, db VisitDatabase, u auth.User) (*impact.VisitReport, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	os, err := db.GetOutcomeSet(questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	meetingsInRange, err := db.GetOSMeetingsInTimeRange(start, end, questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	inRange := getJourneys(meetingsInRange, opts.CompletedOnly)
+	bens := make([]string, 0, len(inRange))
+	for ben := range inRange {
+		bens = append(bens, ben)
+	}
+	sort.Strings(bens)
+
+	report := &impact.VisitReport{
+		QuestionSetID:  questionSetID,
+		BeneficiaryIDs: bens,
+		Steps:          []impact.VisitStep{},
+		Warnings:       []string{},
+	}
+	if len(bens) == 0 {
+		return report, nil
+	}
+
+	meetings, err := db.GetOSMeetingsForBeneficiaries(bens, questionSetID, u)
+	if err != nil {
+		return nil, err
+	}
+	journeys := getJourneys(meetings, opts.CompletedOnly)
+	longest := 0
+	for _, journey := range journeys {
+		if len(journey) > longest {
+			longest = len(journey)
+		}
+	}
+	if opts.MaxVisits > 0 && longest > opts.MaxVisits {
+		longest = opts.MaxVisits
+	}
+
+	agg := meetingAggregator{
+		report:        "VisitReport",
+		questionSetID: questionSetID,
+		u:             u,
+		os:            os,
+	}
+	for visit := 1; visit <= longest; visit++ {
+		step := make([]impact.Meeting, 0, len(bens))
+		for _, ben := range bens {
+			if journey := journeys[ben]; len(journey) >= visit {
+				step = append(step, journey[visit-1])
+			}
+		}
+		report.Steps = append(report.Steps, impact.VisitStep{
+			Visit:              visit,
+			BeneficiaryCount:   len(step),
+			RetainedPercent:    percent(len(step), len(bens)),
+			QuestionAggregates: agg.getQuestionAggregates(step),
+			CategoryAggregates: agg.getCategoryAggregates(step),
+		})
+	}
+	return report, nil
+}
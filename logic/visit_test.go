@@ -0,0 +1,111 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/impactasaurus/server/logic"
+	"github.com/impactasaurus/server/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisitReport(t *testing.T) {
+	end := time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -1, 0)
+	day := func(d int) time.Time {
+		return start.AddDate(0, 0, d)
+	}
+	// B1's meetings span a year, B2's meetings a week
+	b1 := []impact.Meeting{
+		trendMeeting("B1M3", "B1", day(10), map[string]int{"Q1": 9, "Q3": 4}),
+		trendMeeting("B1M1", "B1", day(-300), map[string]int{"Q1": 1, "Q3": 2}),
+		trendMeeting("B1M2", "B1", day(-100), map[string]int{"Q1": 5, "Q3": 3}),
+	}
+	b2 := []impact.Meeting{
+		trendMeeting("B2M1", "B2", day(1), map[string]int{"Q1": 3}),
+		trendMeeting("B2M2", "B2", day(8), map[string]int{"Q1": 7}),
+	}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{b1[0], b2[0], b2[1]}, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiaries([]string{"B1", "B2"}, questionSetID, mockUser).Return(append(b1, b2...), nil)
+
+		report, err := logic.GetVisitReport(start, end, questionSetID, logic.VisitOptions{}, mockDB, mockUser)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, []string{"B1", "B2"}, report.BeneficiaryIDs)
+		if !assert.Len(t, report.Steps, 3) {
+			return
+		}
+
+		first := report.Steps[0]
+		assert.Equal(t, 1, first.Visit)
+		assert.Equal(t, 2, first.BeneficiaryCount)
+		assert.InDelta(t, 100, first.RetainedPercent, 1e-6)
+		assert.Equal(t, "Q1", first.QuestionAggregates[0].QuestionID)
+		assert.InDelta(t, 2, first.QuestionAggregates[0].Value, 1e-6)
+		assert.Equal(t, []string{"B1", "B2"}, first.QuestionAggregates[0].BeneficiaryIDs)
+		if assert.Len(t, first.CategoryAggregates, 2) {
+			assert.InDelta(t, 2, first.CategoryAggregates[1].Value, 1e-6)
+			assert.Equal(t, []string{"B1"}, first.CategoryAggregates[1].BeneficiaryIDs)
+		}
+
+		second := report.Steps[1]
+		assert.Equal(t, 2, second.BeneficiaryCount)
+		assert.InDelta(t, 6, second.QuestionAggregates[0].Value, 1e-6)
+
+		third := report.Steps[2]
+		assert.Equal(t, 3, third.Visit)
+		assert.Equal(t, 1, third.BeneficiaryCount)
+		assert.InDelta(t, 50, third.RetainedPercent, 1e-6)
+		assert.InDelta(t, 9, third.QuestionAggregates[0].Value, 1e-6)
+	})
+}
+
+func TestVisitReportOptions(t *testing.T) {
+	end := time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -1, 0)
+	incomplete := trendMeeting("B1M2", "B1", start.AddDate(0, 0, 2), map[string]int{"Q1": 100})
+	incomplete.Status = impact.INPROGRESS
+	meetings := []impact.Meeting{
+		trendMeeting("B1M1", "B1", start.AddDate(0, 0, 1), map[string]int{"Q1": 1}),
+		incomplete,
+		trendMeeting("B1M3", "B1", start.AddDate(0, 0, 3), map[string]int{"Q1": 3}),
+		trendMeeting("B1M4", "B1", start.AddDate(0, 0, 4), map[string]int{"Q1": 4}),
+	}
+
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return(meetings, nil)
+		mockDB.EXPECT().GetOSMeetingsForBeneficiaries([]string{"B1"}, questionSetID, mockUser).Return(meetings, nil)
+
+		opts := logic.VisitOptions{CompletedOnly: true, MaxVisits: 2}
+		report, err := logic.GetVisitReport(start, end, questionSetID, opts, mockDB, mockUser)
+		if !assert.Nil(t, err) {
+			return
+		}
+		if assert.Len(t, report.Steps, 2) {
+			assert.InDelta(t, 3, report.Steps[1].QuestionAggregates[0].Value, 1e-6)
+		}
+	})
+}
+
+func TestVisitReportNoBeneficiaries(t *testing.T) {
+	end := time.Now()
+	start := end.AddDate(0, -1, 0)
+	setupWrapper(t, func(mockUser *mock.MockUser, mockDB *mock.MockBase) {
+		mockDB.EXPECT().GetOutcomeSet(questionSetID, mockUser).Return(getDefaultOutcomeSet(questionSetID), nil)
+		mockDB.EXPECT().GetOSMeetingsInTimeRange(start, end, questionSetID, mockUser).Return([]impact.Meeting{}, nil)
+
+		report, err := logic.GetVisitReport(start, end, questionSetID, logic.VisitOptions{}, mockDB, mockUser)
+		assert.Nil(t, err)
+		assert.Empty(t, report.BeneficiaryIDs)
+		assert.Empty(t, report.Steps)
+
+		_, err = logic.GetVisitReport(start, end, questionSetID, logic.VisitOptions{MaxVisits: -1}, mockDB, mockUser)
+		assert.IsType(t, impact.ValidationErrors{}, err)
+	})
+}
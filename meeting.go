@@ -7,7 +7,22 @@ import (
 
 type AnswerType string
 
-const INT AnswerType = "int"
+const (
+	INT     AnswerType = "int"
+	STRING  AnswerType = "string"
+	CHOICE  AnswerType = "choice"
+	CHOICES AnswerType = "choices"
+	FLOAT   AnswerType = "float"
+)
+
+// MeetingStatus tracks where a meeting is in its lifecycle
+type MeetingStatus string
+
+const (
+	INPROGRESS MeetingStatus = "inprogress"
+	COMPLETE   MeetingStatus = "complete"
+	ABANDONED  MeetingStatus = "abandoned"
+)
 
 type Answer struct {
 	QuestionID string      `json:"questionID" bson:"questionID"`
@@ -16,15 +31,32 @@ type Answer struct {
 }
 
 type Meeting struct {
-	ID             string    `json:"id" bson:"_id"`
-	Beneficiary    string    `json:"beneficiary"`
-	User           string    `json:"user"`
-	OutcomeSetID   string    `json:"outcomeSetID" bson:"outcomeSetID"`
-	OrganisationID string    `json:"organisationID" bson:"organisationID"`
-	Answers        []Answer  `json:"answers"`
-	Conducted      time.Time `json:"conducted"`
-	Created        time.Time `json:"created"`
-	Modified       time.Time `json:"modified"`
+	ID             string        `json:"id" bson:"_id"`
+	Beneficiary    string        `json:"beneficiary"`
+	User           string        `json:"user"`
+	OutcomeSetID   string        `json:"outcomeSetID" bson:"outcomeSetID"`
+	OrganisationID string        `json:"organisationID" bson:"organisationID"`
+	Answers        []Answer      `json:"answers"`
+	Conducted      time.Time     `json:"conducted"`
+	Created        time.Time     `json:"created"`
+	Modified       time.Time     `json:"modified"`
+	Deleted        bool          `json:"deleted"`
+	Status         MeetingStatus `json:"status"`
+	Baseline       bool          `json:"baseline"`
+}
+
+// GetStatus returns the meeting's status.
+// Meetings recorded before statuses were introduced do not have a status and are considered complete.
+func (m Meeting) GetStatus() MeetingStatus {
+	if m.Status == "" {
+		return COMPLETE
+	}
+	return m.Status
+}
+
+// IsComplete returns true if the meeting has been completed
+func (m Meeting) IsComplete() bool {
+	return m.GetStatus() == COMPLETE
 }
 
 // CategoryAggregate aggregates multiple questions belonging to the same category to a question category level
@@ -39,31 +71,80 @@ type Aggregates struct {
 }
 
 func (a Answer) IsNumeric() bool {
-	return a.Type == INT
+	return a.Type == INT || a.Type == FLOAT
 }
 
 func (a Answer) ToFloat() (float32, error) {
+	f, err := a.ToFloat64()
+	return float32(f), err
+}
+
+// ToFloat64 returns the numeric answer without the loss of precision of ToFloat, float answers are stored as float64s
+func (a Answer) ToFloat64() (float64, error) {
 	switch i := a.Answer.(type) {
 	case float32:
-		return i, nil
+		return float64(i), nil
 	case float64:
-		return float32(i), nil
+		return i, nil
 	case int64:
-		return float32(i), nil
+		return float64(i), nil
 	case int32:
-		return float32(i), nil
+		return float64(i), nil
 	case int:
-		return float32(i), nil
+		return float64(i), nil
 	default:
 		return 0, errors.New("Cannot convert answer to float")
 	}
 }
 
+// ChoiceIDs returns the IDs of the choices selected by a choice answer
+func (a Answer) ChoiceIDs() ([]string, error) {
+	switch i := a.Answer.(type) {
+	case string:
+		return []string{i}, nil
+	case []string:
+		return i, nil
+	case []interface{}:
+		out := make([]string, 0, len(i))
+		for _, c := range i {
+			s, ok := c.(string)
+			if !ok {
+				return nil, errors.New("Choice IDs should be strings")
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, errors.New("Cannot convert answer to choice IDs")
+	}
+}
+
+// GetAnswer returns the answer to the provided question or nil.
+// If the question was answered more than once, the latest answer is returned.
 func (m *Meeting) GetAnswer(questionID string) *Answer {
-	for _, a := range m.Answers {
-		if a.QuestionID == questionID {
+	for i := len(m.Answers) - 1; i >= 0; i-- {
+		if m.Answers[i].QuestionID == questionID {
+			a := m.Answers[i]
 			return &a
 		}
 	}
 	return nil
 }
+
+// RemoveDuplicateAnswers removes all but the latest answer to each question.
+// The number of answers removed is returned.
+func (m *Meeting) RemoveDuplicateAnswers() int {
+	lastIdx := make(map[string]int, len(m.Answers))
+	for i, a := range m.Answers {
+		lastIdx[a.QuestionID] = i
+	}
+	kept := make([]Answer, 0, len(lastIdx))
+	for i, a := range m.Answers {
+		if lastIdx[a.QuestionID] == i {
+			kept = append(kept, a)
+		}
+	}
+	removed := len(m.Answers) - len(kept)
+	m.Answers = kept
+	return removed
+}
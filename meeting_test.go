@@ -0,0 +1,41 @@
+package server_test
+
+import (
+	"testing"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveDuplicateAnswers(t *testing.T) {
+	m := impact.Meeting{
+		Answers: []impact.Answer{
+			{QuestionID: "Q1", Type: impact.INT, Answer: 1},
+			{QuestionID: "Q2", Type: impact.INT, Answer: 2},
+			{QuestionID: "Q1", Type: impact.INT, Answer: 3},
+			{QuestionID: "Q3", Type: impact.INT, Answer: 4},
+			{QuestionID: "Q1", Type: impact.INT, Answer: 5},
+		},
+	}
+	assert.Equal(t, 5, m.GetAnswer("Q1").Answer)
+	assert.Equal(t, 2, m.RemoveDuplicateAnswers())
+	assert.Equal(t, []impact.Answer{
+		{QuestionID: "Q2", Type: impact.INT, Answer: 2},
+		{QuestionID: "Q3", Type: impact.INT, Answer: 4},
+		{QuestionID: "Q1", Type: impact.INT, Answer: 5},
+	}, m.Answers)
+	assert.Equal(t, 0, m.RemoveDuplicateAnswers())
+}
+
+func TestMeetingStatus(t *testing.T) {
+	legacy := impact.Meeting{}
+	assert.Equal(t, impact.COMPLETE, legacy.GetStatus())
+	assert.True(t, legacy.IsComplete())
+
+	inProgress := impact.Meeting{Status: impact.INPROGRESS}
+	assert.Equal(t, impact.INPROGRESS, inProgress.GetStatus())
+	assert.False(t, inProgress.IsComplete())
+
+	abandoned := impact.Meeting{Status: impact.ABANDONED}
+	assert.False(t, abandoned.IsComplete())
+}
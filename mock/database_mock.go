@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	server "github.com/impactasaurus/server"
 	auth "github.com/impactasaurus/server/auth"
+	data "github.com/impactasaurus/server/data"
 )
 
 // MockBase is a mock of Base interface
@@ -36,6 +37,71 @@ func (m *MockBase) EXPECT() *MockBaseMockRecorder {
 	return m.recorder
 }
 
+// AbandonMeeting mocks base method
+func (m *MockBase) AbandonMeeting(arg0 string, arg1 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "AbandonMeeting", arg0, arg1)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbandonMeeting indicates an expected call of AbandonMeeting
+func (mr *MockBaseMockRecorder) AbandonMeeting(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbandonMeeting", reflect.TypeOf((*MockBase)(nil).AbandonMeeting), arg0, arg1)
+}
+
+// AddBeneficiaryTags mocks base method
+func (m *MockBase) AddBeneficiaryTags(arg0 string, arg1 []string, arg2 auth.User) (server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "AddBeneficiaryTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBeneficiaryTags indicates an expected call of AddBeneficiaryTags
+func (mr *MockBaseMockRecorder) AddBeneficiaryTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBeneficiaryTags", reflect.TypeOf((*MockBase)(nil).AddBeneficiaryTags), arg0, arg1, arg2)
+}
+
+// ClearBeneficiaryProfileValue mocks base method
+func (m *MockBase) ClearBeneficiaryProfileValue(arg0, arg1 string, arg2 auth.User) (server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "ClearBeneficiaryProfileValue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearBeneficiaryProfileValue indicates an expected call of ClearBeneficiaryProfileValue
+func (mr *MockBaseMockRecorder) ClearBeneficiaryProfileValue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearBeneficiaryProfileValue", reflect.TypeOf((*MockBase)(nil).ClearBeneficiaryProfileValue), arg0, arg1, arg2)
+}
+
+// CompleteMeeting mocks base method
+func (m *MockBase) CompleteMeeting(arg0 string, arg1 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "CompleteMeeting", arg0, arg1)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMeeting indicates an expected call of CompleteMeeting
+func (mr *MockBaseMockRecorder) CompleteMeeting(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMeeting", reflect.TypeOf((*MockBase)(nil).CompleteMeeting), arg0, arg1)
+}
+
+// DeleteAnswer mocks base method
+func (m *MockBase) DeleteAnswer(arg0, arg1 string, arg2 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "DeleteAnswer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAnswer indicates an expected call of DeleteAnswer
+func (mr *MockBaseMockRecorder) DeleteAnswer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnswer", reflect.TypeOf((*MockBase)(nil).DeleteAnswer), arg0, arg1, arg2)
+}
+
 // DeleteCategory mocks base method
 func (m *MockBase) DeleteCategory(arg0, arg1 string, arg2 auth.User) error {
 	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1, arg2)
@@ -48,6 +114,18 @@ func (mr *MockBaseMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockBase)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteMeeting mocks base method
+func (m *MockBase) DeleteMeeting(arg0 string, arg1 auth.User) error {
+	ret := m.ctrl.Call(m, "DeleteMeeting", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMeeting indicates an expected call of DeleteMeeting
+func (mr *MockBaseMockRecorder) DeleteMeeting(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeeting", reflect.TypeOf((*MockBase)(nil).DeleteMeeting), arg0, arg1)
+}
+
 // DeleteOutcomeSet mocks base method
 func (m *MockBase) DeleteOutcomeSet(arg0 string, arg1 auth.User) error {
 	ret := m.ctrl.Call(m, "DeleteOutcomeSet", arg0, arg1)
@@ -60,6 +138,18 @@ func (mr *MockBaseMockRecorder) DeleteOutcomeSet(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutcomeSet", reflect.TypeOf((*MockBase)(nil).DeleteOutcomeSet), arg0, arg1)
 }
 
+// DeleteProfileField mocks base method
+func (m *MockBase) DeleteProfileField(arg0 string, arg1 auth.User) error {
+	ret := m.ctrl.Call(m, "DeleteProfileField", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProfileField indicates an expected call of DeleteProfileField
+func (mr *MockBaseMockRecorder) DeleteProfileField(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfileField", reflect.TypeOf((*MockBase)(nil).DeleteProfileField), arg0, arg1)
+}
+
 // DeleteQuestion mocks base method
 func (m *MockBase) DeleteQuestion(arg0, arg1 string, arg2 auth.User) error {
 	ret := m.ctrl.Call(m, "DeleteQuestion", arg0, arg1, arg2)
@@ -72,6 +162,45 @@ func (mr *MockBaseMockRecorder) DeleteQuestion(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuestion", reflect.TypeOf((*MockBase)(nil).DeleteQuestion), arg0, arg1, arg2)
 }
 
+// EditAnswer mocks base method
+func (m *MockBase) EditAnswer(arg0 string, arg1 server.Answer, arg2 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "EditAnswer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditAnswer indicates an expected call of EditAnswer
+func (mr *MockBaseMockRecorder) EditAnswer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditAnswer", reflect.TypeOf((*MockBase)(nil).EditAnswer), arg0, arg1, arg2)
+}
+
+// EditCategory mocks base method
+func (m *MockBase) EditCategory(arg0, arg1, arg2, arg3 string, arg4 server.Aggregation, arg5 auth.User) (server.Category, error) {
+	ret := m.ctrl.Call(m, "EditCategory", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(server.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditCategory indicates an expected call of EditCategory
+func (mr *MockBaseMockRecorder) EditCategory(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditCategory", reflect.TypeOf((*MockBase)(nil).EditCategory), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// EditChoiceQuestion mocks base method
+func (m *MockBase) EditChoiceQuestion(arg0, arg1, arg2, arg3 string, arg4 []server.Choice, arg5 auth.User) (server.Question, error) {
+	ret := m.ctrl.Call(m, "EditChoiceQuestion", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(server.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditChoiceQuestion indicates an expected call of EditChoiceQuestion
+func (mr *MockBaseMockRecorder) EditChoiceQuestion(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditChoiceQuestion", reflect.TypeOf((*MockBase)(nil).EditChoiceQuestion), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // EditOutcomeSet mocks base method
 func (m *MockBase) EditOutcomeSet(arg0, arg1, arg2 string, arg3 auth.User) (server.OutcomeSet, error) {
 	ret := m.ctrl.Call(m, "EditOutcomeSet", arg0, arg1, arg2, arg3)
@@ -85,6 +214,19 @@ func (mr *MockBaseMockRecorder) EditOutcomeSet(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditOutcomeSet", reflect.TypeOf((*MockBase)(nil).EditOutcomeSet), arg0, arg1, arg2, arg3)
 }
 
+// EditProfileField mocks base method
+func (m *MockBase) EditProfileField(arg0, arg1, arg2 string, arg3 []string, arg4 auth.User) (server.ProfileField, error) {
+	ret := m.ctrl.Call(m, "EditProfileField", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(server.ProfileField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditProfileField indicates an expected call of EditProfileField
+func (mr *MockBaseMockRecorder) EditProfileField(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditProfileField", reflect.TypeOf((*MockBase)(nil).EditProfileField), arg0, arg1, arg2, arg3, arg4)
+}
+
 // EditQuestion mocks base method
 func (m *MockBase) EditQuestion(arg0, arg1, arg2, arg3 string, arg4 server.QuestionType, arg5 map[string]interface{}, arg6 auth.User) (server.Question, error) {
 	ret := m.ctrl.Call(m, "EditQuestion", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
@@ -98,6 +240,71 @@ func (mr *MockBaseMockRecorder) EditQuestion(arg0, arg1, arg2, arg3, arg4, arg5,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditQuestion", reflect.TypeOf((*MockBase)(nil).EditQuestion), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// EraseBeneficiary mocks base method
+func (m *MockBase) EraseBeneficiary(arg0 string, arg1 auth.User) (server.Erasure, error) {
+	ret := m.ctrl.Call(m, "EraseBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(server.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseBeneficiary indicates an expected call of EraseBeneficiary
+func (mr *MockBaseMockRecorder) EraseBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseBeneficiary", reflect.TypeOf((*MockBase)(nil).EraseBeneficiary), arg0, arg1)
+}
+
+// GetBeneficiaries mocks base method
+func (m *MockBase) GetBeneficiaries(arg0 bool, arg1 auth.User) ([]server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "GetBeneficiaries", arg0, arg1)
+	ret0, _ := ret[0].([]server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaries indicates an expected call of GetBeneficiaries
+func (mr *MockBaseMockRecorder) GetBeneficiaries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaries", reflect.TypeOf((*MockBase)(nil).GetBeneficiaries), arg0, arg1)
+}
+
+// GetBeneficiary mocks base method
+func (m *MockBase) GetBeneficiary(arg0 string, arg1 auth.User) (server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "GetBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary
+func (mr *MockBaseMockRecorder) GetBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockBase)(nil).GetBeneficiary), arg0, arg1)
+}
+
+// GetBeneficiaryChanges mocks base method
+func (m *MockBase) GetBeneficiaryChanges(arg0 string, arg1 auth.User) ([]server.BeneficiaryChange, error) {
+	ret := m.ctrl.Call(m, "GetBeneficiaryChanges", arg0, arg1)
+	ret0, _ := ret[0].([]server.BeneficiaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaryChanges indicates an expected call of GetBeneficiaryChanges
+func (mr *MockBaseMockRecorder) GetBeneficiaryChanges(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaryChanges", reflect.TypeOf((*MockBase)(nil).GetBeneficiaryChanges), arg0, arg1)
+}
+
+// GetBeneficiaryTags mocks base method
+func (m *MockBase) GetBeneficiaryTags(arg0 auth.User) ([]string, error) {
+	ret := m.ctrl.Call(m, "GetBeneficiaryTags", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaryTags indicates an expected call of GetBeneficiaryTags
+func (mr *MockBaseMockRecorder) GetBeneficiaryTags(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaryTags", reflect.TypeOf((*MockBase)(nil).GetBeneficiaryTags), arg0)
+}
+
 // GetCategory mocks base method
 func (m *MockBase) GetCategory(arg0, arg1 string, arg2 auth.User) (server.Category, error) {
 	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1, arg2)
@@ -111,6 +318,19 @@ func (mr *MockBaseMockRecorder) GetCategory(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockBase)(nil).GetCategory), arg0, arg1, arg2)
 }
 
+// GetErasures mocks base method
+func (m *MockBase) GetErasures(arg0 string, arg1 auth.User) ([]server.Erasure, error) {
+	ret := m.ctrl.Call(m, "GetErasures", arg0, arg1)
+	ret0, _ := ret[0].([]server.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasures indicates an expected call of GetErasures
+func (mr *MockBaseMockRecorder) GetErasures(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasures", reflect.TypeOf((*MockBase)(nil).GetErasures), arg0, arg1)
+}
+
 // GetMeeting mocks base method
 func (m *MockBase) GetMeeting(arg0 string, arg1 auth.User) (server.Meeting, error) {
 	ret := m.ctrl.Call(m, "GetMeeting", arg0, arg1)
@@ -124,6 +344,19 @@ func (mr *MockBaseMockRecorder) GetMeeting(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeeting", reflect.TypeOf((*MockBase)(nil).GetMeeting), arg0, arg1)
 }
 
+// GetMeetings mocks base method
+func (m *MockBase) GetMeetings(arg0 data.MeetingFilter, arg1 data.MeetingPageRequest, arg2 auth.User) (data.MeetingPage, error) {
+	ret := m.ctrl.Call(m, "GetMeetings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(data.MeetingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetings indicates an expected call of GetMeetings
+func (mr *MockBaseMockRecorder) GetMeetings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetings", reflect.TypeOf((*MockBase)(nil).GetMeetings), arg0, arg1, arg2)
+}
+
 // GetMeetingsForBeneficiary mocks base method
 func (m *MockBase) GetMeetingsForBeneficiary(arg0 string, arg1 auth.User) ([]server.Meeting, error) {
 	ret := m.ctrl.Call(m, "GetMeetingsForBeneficiary", arg0, arg1)
@@ -137,6 +370,19 @@ func (mr *MockBaseMockRecorder) GetMeetingsForBeneficiary(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingsForBeneficiary", reflect.TypeOf((*MockBase)(nil).GetMeetingsForBeneficiary), arg0, arg1)
 }
 
+// GetOSMeetingsForBeneficiaries mocks base method
+func (m *MockBase) GetOSMeetingsForBeneficiaries(arg0 []string, arg1 string, arg2 auth.User) ([]server.Meeting, error) {
+	ret := m.ctrl.Call(m, "GetOSMeetingsForBeneficiaries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOSMeetingsForBeneficiaries indicates an expected call of GetOSMeetingsForBeneficiaries
+func (mr *MockBaseMockRecorder) GetOSMeetingsForBeneficiaries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOSMeetingsForBeneficiaries", reflect.TypeOf((*MockBase)(nil).GetOSMeetingsForBeneficiaries), arg0, arg1, arg2)
+}
+
 // GetOSMeetingsForBeneficiary mocks base method
 func (m *MockBase) GetOSMeetingsForBeneficiary(arg0, arg1 string, arg2 auth.User) ([]server.Meeting, error) {
 	ret := m.ctrl.Call(m, "GetOSMeetingsForBeneficiary", arg0, arg1, arg2)
@@ -215,6 +461,19 @@ func (mr *MockBaseMockRecorder) GetQuestion(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestion", reflect.TypeOf((*MockBase)(nil).GetQuestion), arg0, arg1, arg2)
 }
 
+// MergeBeneficiaries mocks base method
+func (m *MockBase) MergeBeneficiaries(arg0, arg1 string, arg2 auth.User) (server.BeneficiaryChange, error) {
+	ret := m.ctrl.Call(m, "MergeBeneficiaries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.BeneficiaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeBeneficiaries indicates an expected call of MergeBeneficiaries
+func (mr *MockBaseMockRecorder) MergeBeneficiaries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBeneficiaries", reflect.TypeOf((*MockBase)(nil).MergeBeneficiaries), arg0, arg1, arg2)
+}
+
 // MoveQuestion mocks base method
 func (m *MockBase) MoveQuestion(arg0, arg1 string, arg2 uint, arg3 auth.User) error {
 	ret := m.ctrl.Call(m, "MoveQuestion", arg0, arg1, arg2, arg3)
@@ -253,6 +512,19 @@ func (mr *MockBaseMockRecorder) NewCategory(arg0, arg1, arg2, arg3, arg4 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCategory", reflect.TypeOf((*MockBase)(nil).NewCategory), arg0, arg1, arg2, arg3, arg4)
 }
 
+// NewChoiceQuestion mocks base method
+func (m *MockBase) NewChoiceQuestion(arg0, arg1, arg2 string, arg3 server.QuestionType, arg4 []server.Choice, arg5 auth.User) (server.Question, error) {
+	ret := m.ctrl.Call(m, "NewChoiceQuestion", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(server.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewChoiceQuestion indicates an expected call of NewChoiceQuestion
+func (mr *MockBaseMockRecorder) NewChoiceQuestion(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewChoiceQuestion", reflect.TypeOf((*MockBase)(nil).NewChoiceQuestion), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // NewMeeting mocks base method
 func (m *MockBase) NewMeeting(arg0, arg1 string, arg2 time.Time, arg3 auth.User) (server.Meeting, error) {
 	ret := m.ctrl.Call(m, "NewMeeting", arg0, arg1, arg2, arg3)
@@ -266,6 +538,19 @@ func (mr *MockBaseMockRecorder) NewMeeting(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMeeting", reflect.TypeOf((*MockBase)(nil).NewMeeting), arg0, arg1, arg2, arg3)
 }
 
+// NewMeetingWithAnswers mocks base method
+func (m *MockBase) NewMeetingWithAnswers(arg0, arg1 string, arg2 time.Time, arg3 []server.Answer, arg4 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "NewMeetingWithAnswers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMeetingWithAnswers indicates an expected call of NewMeetingWithAnswers
+func (mr *MockBaseMockRecorder) NewMeetingWithAnswers(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMeetingWithAnswers", reflect.TypeOf((*MockBase)(nil).NewMeetingWithAnswers), arg0, arg1, arg2, arg3, arg4)
+}
+
 // NewOutcomeSet mocks base method
 func (m *MockBase) NewOutcomeSet(arg0, arg1 string, arg2 auth.User) (server.OutcomeSet, error) {
 	ret := m.ctrl.Call(m, "NewOutcomeSet", arg0, arg1, arg2)
@@ -279,6 +564,19 @@ func (mr *MockBaseMockRecorder) NewOutcomeSet(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOutcomeSet", reflect.TypeOf((*MockBase)(nil).NewOutcomeSet), arg0, arg1, arg2)
 }
 
+// NewProfileField mocks base method
+func (m *MockBase) NewProfileField(arg0, arg1 string, arg2 server.ProfileFieldType, arg3 []string, arg4 auth.User) (server.ProfileField, error) {
+	ret := m.ctrl.Call(m, "NewProfileField", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(server.ProfileField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewProfileField indicates an expected call of NewProfileField
+func (mr *MockBaseMockRecorder) NewProfileField(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewProfileField", reflect.TypeOf((*MockBase)(nil).NewProfileField), arg0, arg1, arg2, arg3, arg4)
+}
+
 // NewQuestion mocks base method
 func (m *MockBase) NewQuestion(arg0, arg1, arg2 string, arg3 server.QuestionType, arg4 map[string]interface{}, arg5 auth.User) (server.Question, error) {
 	ret := m.ctrl.Call(m, "NewQuestion", arg0, arg1, arg2, arg3, arg4, arg5)
@@ -292,6 +590,19 @@ func (mr *MockBaseMockRecorder) NewQuestion(arg0, arg1, arg2, arg3, arg4, arg5 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewQuestion", reflect.TypeOf((*MockBase)(nil).NewQuestion), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// RemoveBeneficiaryTags mocks base method
+func (m *MockBase) RemoveBeneficiaryTags(arg0 string, arg1 []string, arg2 auth.User) (server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "RemoveBeneficiaryTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveBeneficiaryTags indicates an expected call of RemoveBeneficiaryTags
+func (mr *MockBaseMockRecorder) RemoveBeneficiaryTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBeneficiaryTags", reflect.TypeOf((*MockBase)(nil).RemoveBeneficiaryTags), arg0, arg1, arg2)
+}
+
 // RemoveCategory mocks base method
 func (m *MockBase) RemoveCategory(arg0, arg1 string, arg2 auth.User) (server.Question, error) {
 	ret := m.ctrl.Call(m, "RemoveCategory", arg0, arg1, arg2)
@@ -305,6 +616,71 @@ func (mr *MockBaseMockRecorder) RemoveCategory(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategory", reflect.TypeOf((*MockBase)(nil).RemoveCategory), arg0, arg1, arg2)
 }
 
+// RenameBeneficiary mocks base method
+func (m *MockBase) RenameBeneficiary(arg0, arg1 string, arg2 auth.User) (server.BeneficiaryChange, error) {
+	ret := m.ctrl.Call(m, "RenameBeneficiary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.BeneficiaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameBeneficiary indicates an expected call of RenameBeneficiary
+func (mr *MockBaseMockRecorder) RenameBeneficiary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameBeneficiary", reflect.TypeOf((*MockBase)(nil).RenameBeneficiary), arg0, arg1, arg2)
+}
+
+// RestoreMeeting mocks base method
+func (m *MockBase) RestoreMeeting(arg0 string, arg1 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "RestoreMeeting", arg0, arg1)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreMeeting indicates an expected call of RestoreMeeting
+func (mr *MockBaseMockRecorder) RestoreMeeting(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMeeting", reflect.TypeOf((*MockBase)(nil).RestoreMeeting), arg0, arg1)
+}
+
+// ReverseBeneficiaryChange mocks base method
+func (m *MockBase) ReverseBeneficiaryChange(arg0 string, arg1 auth.User) (server.BeneficiaryChange, error) {
+	ret := m.ctrl.Call(m, "ReverseBeneficiaryChange", arg0, arg1)
+	ret0, _ := ret[0].(server.BeneficiaryChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseBeneficiaryChange indicates an expected call of ReverseBeneficiaryChange
+func (mr *MockBaseMockRecorder) ReverseBeneficiaryChange(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseBeneficiaryChange", reflect.TypeOf((*MockBase)(nil).ReverseBeneficiaryChange), arg0, arg1)
+}
+
+// SetBeneficiaryArchived mocks base method
+func (m *MockBase) SetBeneficiaryArchived(arg0 string, arg1 bool, arg2 auth.User) (server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "SetBeneficiaryArchived", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBeneficiaryArchived indicates an expected call of SetBeneficiaryArchived
+func (mr *MockBaseMockRecorder) SetBeneficiaryArchived(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBeneficiaryArchived", reflect.TypeOf((*MockBase)(nil).SetBeneficiaryArchived), arg0, arg1, arg2)
+}
+
+// SetBeneficiaryProfileValues mocks base method
+func (m *MockBase) SetBeneficiaryProfileValues(arg0 string, arg1 map[string]interface{}, arg2 auth.User) (server.Beneficiary, error) {
+	ret := m.ctrl.Call(m, "SetBeneficiaryProfileValues", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBeneficiaryProfileValues indicates an expected call of SetBeneficiaryProfileValues
+func (mr *MockBaseMockRecorder) SetBeneficiaryProfileValues(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBeneficiaryProfileValues", reflect.TypeOf((*MockBase)(nil).SetBeneficiaryProfileValues), arg0, arg1, arg2)
+}
+
 // SetCategory mocks base method
 func (m *MockBase) SetCategory(arg0, arg1, arg2 string, arg3 auth.User) (server.Question, error) {
 	ret := m.ctrl.Call(m, "SetCategory", arg0, arg1, arg2, arg3)
@@ -317,3 +693,42 @@ func (m *MockBase) SetCategory(arg0, arg1, arg2 string, arg3 auth.User) (server.
 func (mr *MockBaseMockRecorder) SetCategory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategory", reflect.TypeOf((*MockBase)(nil).SetCategory), arg0, arg1, arg2, arg3)
 }
+
+// SetCategoryChangeThreshold mocks base method
+func (m *MockBase) SetCategoryChangeThreshold(arg0, arg1 string, arg2 *server.ChangeThreshold, arg3 auth.User) (server.Category, error) {
+	ret := m.ctrl.Call(m, "SetCategoryChangeThreshold", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(server.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategoryChangeThreshold indicates an expected call of SetCategoryChangeThreshold
+func (mr *MockBaseMockRecorder) SetCategoryChangeThreshold(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryChangeThreshold", reflect.TypeOf((*MockBase)(nil).SetCategoryChangeThreshold), arg0, arg1, arg2, arg3)
+}
+
+// SetMeetingBaseline mocks base method
+func (m *MockBase) SetMeetingBaseline(arg0 string, arg1 bool, arg2 auth.User) (server.Meeting, error) {
+	ret := m.ctrl.Call(m, "SetMeetingBaseline", arg0, arg1, arg2)
+	ret0, _ := ret[0].(server.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMeetingBaseline indicates an expected call of SetMeetingBaseline
+func (mr *MockBaseMockRecorder) SetMeetingBaseline(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMeetingBaseline", reflect.TypeOf((*MockBase)(nil).SetMeetingBaseline), arg0, arg1, arg2)
+}
+
+// SetOrganisationTimeZone mocks base method
+func (m *MockBase) SetOrganisationTimeZone(arg0 string, arg1 auth.User) (server.Organisation, error) {
+	ret := m.ctrl.Call(m, "SetOrganisationTimeZone", arg0, arg1)
+	ret0, _ := ret[0].(server.Organisation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganisationTimeZone indicates an expected call of SetOrganisationTimeZone
+func (mr *MockBaseMockRecorder) SetOrganisationTimeZone(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganisationTimeZone", reflect.TypeOf((*MockBase)(nil).SetOrganisationTimeZone), arg0, arg1)
+}
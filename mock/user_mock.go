@@ -33,6 +33,18 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 	return m.recorder
 }
 
+// IsAdmin mocks base method
+func (m *MockUser) IsAdmin() bool {
+	ret := m.ctrl.Call(m, "IsAdmin")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdmin indicates an expected call of IsAdmin
+func (mr *MockUserMockRecorder) IsAdmin() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockUser)(nil).IsAdmin))
+}
+
 // Organisation mocks base method
 func (m *MockUser) Organisation() (string, error) {
 	ret := m.ctrl.Call(m, "Organisation")
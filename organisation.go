@@ -1,6 +1,39 @@
 package server
 
+import "time"
+
 type Organisation struct {
-	Name string `json:"name"`
-	ID   string `json:"id" bson:"_id"`
+	Name          string         `json:"name"`
+	ID            string         `json:"id" bson:"_id"`
+	ProfileFields []ProfileField `json:"profileFields" bson:"profileFields"`
+	TimeZone      string         `json:"timeZone" bson:"timeZone"`
+}
+
+// Location returns the organisation's time zone. Organisations without a time zone use UTC.
+func (o *Organisation) Location() (*time.Location, error) {
+	if o.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(o.TimeZone)
+}
+
+// GetProfileField returns the profile field with the provided ID or nil
+func (o *Organisation) GetProfileField(id string) *ProfileField {
+	for i := range o.ProfileFields {
+		if o.ProfileFields[i].ID == id {
+			return &o.ProfileFields[i]
+		}
+	}
+	return nil
+}
+
+// ActiveProfileFields returns the profile fields which have not been deleted
+func (o *Organisation) ActiveProfileFields() []ProfileField {
+	fs := make([]ProfileField, 0, len(o.ProfileFields))
+	for _, f := range o.ProfileFields {
+		if !f.Deleted {
+			fs = append(fs, f)
+		}
+	}
+	return fs
 }
@@ -1,8 +1,19 @@
 package server
 
+import (
+	"fmt"
+	"math"
+)
+
 type QuestionType string
 
-const LIKERT QuestionType = "likert"
+const (
+	LIKERT       QuestionType = "likert"
+	FREETEXT     QuestionType = "freetext"
+	SINGLECHOICE QuestionType = "singlechoice"
+	MULTICHOICE  QuestionType = "multichoice"
+	NUMERIC      QuestionType = "numeric"
+)
 
 type Aggregation string
 
@@ -11,6 +22,15 @@ const (
 	SUM  Aggregation = "sum"
 )
 
+// Choice is an option which can be selected when answering a choice question
+type Choice struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label"`
+	Score *float32 `json:"score"`
+	// Deleted choices are kept so answers which selected them can still be described and scored
+	Deleted bool `json:"deleted"`
+}
+
 type Question struct {
 	ID          string                 `json:"id"`
 	Question    string                 `json:"question"`
@@ -18,14 +38,137 @@ type Question struct {
 	Type        QuestionType           `json:"type"`
 	Deleted     bool                   `json:"deleted"`
 	Options     map[string]interface{} `json:"options"`
+	Choices     []Choice               `json:"choices" bson:"choices,omitempty"`
 	CategoryID  string                 `json:"categoryID"  bson:"categoryID"`
 }
 
+// IsChoice returns true if the question is answered by selecting from a list of choices
+func (q Question) IsChoice() bool {
+	return q.Type == SINGLECHOICE || q.Type == MULTICHOICE
+}
+
+// IsNumeric returns true if answers to the question can be aggregated.
+// Choice questions are only numeric if every choice has a score.
+func (q Question) IsNumeric() bool {
+	if q.IsChoice() {
+		if len(q.Choices) == 0 {
+			return false
+		}
+		for _, c := range q.Choices {
+			if c.Score == nil {
+				return false
+			}
+		}
+		return true
+	}
+	return q.Type == LIKERT || q.Type == NUMERIC
+}
+
+// GetFloatOption returns the numeric option with the provided key.
+// The returned bool is false if the option is not set or is not a number.
+func (q Question) GetFloatOption(key string) (float64, bool) {
+	switch i := q.Options[key].(type) {
+	case float64:
+		return i, true
+	case float32:
+		return float64(i), true
+	case int:
+		return float64(i), true
+	case int64:
+		return float64(i), true
+	case int32:
+		return float64(i), true
+	default:
+		return 0, false
+	}
+}
+
+// CheckNumericBounds returns an error if the value falls outside the minValue and maxValue options
+// or is not a multiple of the step option. Options which are not set are not enforced.
+func (q Question) CheckNumericBounds(value float64) error {
+	min, hasMin := q.GetFloatOption("minValue")
+	if hasMin && value < min {
+		return fmt.Errorf("Value must be greater than or equal to %v", min)
+	}
+	if max, ok := q.GetFloatOption("maxValue"); ok && value > max {
+		return fmt.Errorf("Value must be less than or equal to %v", max)
+	}
+	if step, ok := q.GetFloatOption("step"); ok && step > 0 {
+		steps := (value - min) / step
+		if math.Abs(steps-math.Floor(steps+0.5)) > 1e-9 {
+			return fmt.Errorf("Value must be a multiple of %v", step)
+		}
+	}
+	return nil
+}
+
+// GetChoice returns the choice with the provided ID or nil
+func (q Question) GetChoice(choiceID string) *Choice {
+	for _, c := range q.Choices {
+		if c.ID == choiceID {
+			return &c
+		}
+	}
+	return nil
+}
+
+// EditChoices returns the question's choices after an edit providing the choices in the order they should be displayed.
+// Existing choices are identified by their ID, choices without an ID are new. Existing choices which are not provided
+// are kept after the provided choices, marked as deleted with their score, so answers which selected them can still be scored.
+// The score of an existing choice cannot be removed, as answers may have been scored using it.
+func (q Question) EditChoices(choices []Choice) ([]Choice, error) {
+	provided := map[string]bool{}
+	out := make([]Choice, 0, len(choices)+len(q.Choices))
+	for _, c := range choices {
+		if c.ID != "" {
+			existing := q.GetChoice(c.ID)
+			if existing == nil {
+				return nil, fmt.Errorf("Choice %s does not belong to the question", c.ID)
+			}
+			if provided[c.ID] {
+				return nil, fmt.Errorf("Choice %s was provided more than once", c.ID)
+			}
+			if existing.Score != nil && c.Score == nil {
+				return nil, fmt.Errorf("The score of choice %s cannot be removed as answers may have been scored using it", c.ID)
+			}
+			provided[c.ID] = true
+		}
+		c.Deleted = false
+		out = append(out, c)
+	}
+	for _, c := range q.Choices {
+		if !provided[c.ID] {
+			c.Deleted = true
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// ChangeThresholdType determines how a category's change threshold value is interpreted
+type ChangeThresholdType string
+
+const (
+	// ABSOLUTETHRESHOLD treats the value as the smallest change in the category's aggregate which is meaningful
+	ABSOLUTETHRESHOLD ChangeThresholdType = "absolute"
+	// RCITHRESHOLD treats the value as the reliability of the category's measure, between 0 and 1.
+	// The threshold is the reliable change index, 1.96 * sqrt(2) * SD * sqrt(1 - reliability), where SD is the
+	// standard deviation of the beneficiaries' first meeting values.
+	RCITHRESHOLD ChangeThresholdType = "rci"
+)
+
+// ChangeThreshold sets how much a category's aggregate must change before a beneficiary is considered to have improved or declined
+type ChangeThreshold struct {
+	Type  ChangeThresholdType `json:"type"`
+	Value float32             `json:"value"`
+}
+
 type Category struct {
-	ID          string      `json:"id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Aggregation Aggregation `json:"aggregation"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	Aggregation     Aggregation      `json:"aggregation"`
+	ChangeThreshold *ChangeThreshold `json:"changeThreshold" bson:"changeThreshold,omitempty"`
 }
 
 type OutcomeSet struct {
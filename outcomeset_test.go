@@ -0,0 +1,55 @@
+package server_test
+
+import (
+	"testing"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func getChoiceQuestion() impact.Question {
+	low, high := float32(1), float32(3)
+	return impact.Question{
+		ID:   "Q1",
+		Type: impact.SINGLECHOICE,
+		Choices: []impact.Choice{
+			{ID: "low", Label: "Low", Score: &low},
+			{ID: "high", Label: "High", Score: &high},
+		},
+	}
+}
+
+func TestEditChoicesKeepsRemovedChoices(t *testing.T) {
+	q := getChoiceQuestion()
+	high, mid := float32(4), float32(2)
+	choices, err := q.EditChoices([]impact.Choice{
+		{ID: "high", Label: "Very high", Score: &high},
+		{Label: "Medium", Score: &mid},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []impact.Choice{
+		{ID: "high", Label: "Very high", Score: &high},
+		{Label: "Medium", Score: &mid},
+		{ID: "low", Label: "Low", Score: q.Choices[0].Score, Deleted: true},
+	}, choices)
+
+	q.Choices = choices
+	assert.True(t, q.IsNumeric())
+	restored, err := q.EditChoices([]impact.Choice{{ID: "low", Label: "Low", Score: q.Choices[2].Score}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, restored[0].Deleted)
+}
+
+func TestEditChoicesInvalid(t *testing.T) {
+	q := getChoiceQuestion()
+	_, err := q.EditChoices([]impact.Choice{{ID: "missing", Label: "Missing"}})
+	assert.EqualError(t, err, "Choice missing does not belong to the question")
+	_, err = q.EditChoices([]impact.Choice{{ID: "low", Label: "Low"}})
+	assert.EqualError(t, err, "The score of choice low cannot be removed as answers may have been scored using it")
+	_, err = q.EditChoices([]impact.Choice{q.Choices[0], q.Choices[0]})
+	assert.EqualError(t, err, "Choice low was provided more than once")
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProfileFieldType defines the kind of value which can be stored in a profile field
+type ProfileFieldType string
+
+const (
+	ENUMFIELD   ProfileFieldType = "enum"
+	DATEFIELD   ProfileFieldType = "date"
+	NUMBERFIELD ProfileFieldType = "number"
+	TEXTFIELD   ProfileFieldType = "text"
+)
+
+// ProfileField is an organisation defined piece of information which can be recorded against beneficiaries,
+// for example their gender or date of birth. Enum fields are restricted to the listed options.
+type ProfileField struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Type        ProfileFieldType `json:"type"`
+	Options     []string         `json:"options" bson:"options,omitempty"`
+	Deleted     bool             `json:"deleted"`
+}
+
+// HasOption returns true if the option is one of the enum field's options
+func (f ProfileField) HasOption(option string) bool {
+	for _, o := range f.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// ProfileGrouping configures how beneficiaries' profile values are grouped when a report is broken down by a profile field
+type ProfileGrouping struct {
+	// Bands are ascending boundaries which split number fields, or the ages in whole years of date fields, into bands.
+	// For example, 18, 25 and 65 gives the bands under 18, 18 to under 25, 25 to under 65 and 65 and over.
+	Bands []float64
+	// PrefixLength groups text fields by their first characters, ignoring case and spaces.
+	// For example, a prefix length of 2 groups UK postcodes by their first two characters.
+	PrefixLength int
+	// AsOf is when the ages of date fields are measured
+	AsOf time.Time
+}
+
+// ageInYears returns the number of whole years between the date and asOf
+func ageInYears(date, asOf time.Time) float64 {
+	years := asOf.Year() - date.Year()
+	if asOf.Month() < date.Month() || (asOf.Month() == date.Month() && asOf.Day() < date.Day()) {
+		years--
+	}
+	return float64(years)
+}
+
+func formatBand(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// bandLabel returns the label of the band the value falls within and the band's position
+func (g ProfileGrouping) bandLabel(value float64) (string, int) {
+	idx := sort.Search(len(g.Bands), func(i int) bool {
+		return g.Bands[i] > value
+	})
+	switch idx {
+	case 0:
+		return "Under " + formatBand(g.Bands[0]), idx
+	case len(g.Bands):
+		return formatBand(g.Bands[idx-1]) + " and over", idx
+	default:
+		return fmt.Sprintf("%s to under %s", formatBand(g.Bands[idx-1]), formatBand(g.Bands[idx])), idx
+	}
+}
+
+// GroupLabel converts a beneficiary's value for the field into the label of the group the beneficiary
+// belongs to when breaking a report down by the field. The returned rank orders banded groups, groups of
+// the same rank should be ordered by label. Without bands, dates are grouped by year. Without a prefix length,
+// other values are used as is. An empty label is returned if the beneficiary does not have a value.
+func (f ProfileField) GroupLabel(value interface{}, g ProfileGrouping) (string, int) {
+	switch v := value.(type) {
+	case nil:
+		return "", 0
+	case time.Time:
+		if len(g.Bands) > 0 {
+			return g.bandLabel(ageInYears(v, g.AsOf))
+		}
+		return strconv.Itoa(v.Year()), 0
+	case float64:
+		if len(g.Bands) > 0 {
+			return g.bandLabel(v)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), 0
+	case string:
+		if g.PrefixLength > 0 {
+			r := []rune(strings.ToUpper(strings.Replace(v, " ", "", -1)))
+			if len(r) > g.PrefixLength {
+				r = r[:g.PrefixLength]
+			}
+			return string(r), 0
+		}
+		return v, 0
+	default:
+		return fmt.Sprint(v), 0
+	}
+}
@@ -1,19 +1,74 @@
 package server
 
+import "time"
+
+// HistogramBin counts the aggregated values between Min (inclusive) and Max (exclusive).
+// The last bin of a histogram also includes values equal to its Max.
+type HistogramBin struct {
+	Min   float32 `json:"min"`
+	Max   float32 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// DeltaSignificance describes whether the change between beneficiaries' first and last meetings is statistically meaningful.
+// It is only provided for delta aggregates. Values which could not be calculated are nil, Warnings explains why and flags small samples.
+type DeltaSignificance struct {
+	// PairedTTestP is the two sided p-value of a paired t-test
+	PairedTTestP *float32 `json:"pairedTTestP"`
+	// WilcoxonP is the two sided p-value of a Wilcoxon signed-rank test
+	WilcoxonP *float32 `json:"wilcoxonP"`
+	// CohensD is the mean change divided by the standard deviation of the changes
+	CohensD *float32 `json:"cohensD"`
+	// CILower and CIUpper bound the 95% confidence interval for the mean change
+	CILower  *float32 `json:"ciLower"`
+	CIUpper  *float32 `json:"ciUpper"`
+	Warnings []string `json:"warnings"`
+}
+
+// ChangeCounts counts the beneficiaries whose value went up, stayed the same or went down between their first and last meetings.
+// A change must be larger than Threshold to count as an improvement or decline. Percentages are between 0 and 100.
+type ChangeCounts struct {
+	Threshold        float32 `json:"threshold"`
+	Improved         int     `json:"improved"`
+	Unchanged        int     `json:"unchanged"`
+	Declined         int     `json:"declined"`
+	ImprovedPercent  float32 `json:"improvedPercent"`
+	UnchangedPercent float32 `json:"unchangedPercent"`
+	DeclinedPercent  float32 `json:"declinedPercent"`
+}
+
 // CatBenAgg is a BenAgg associated with a question category
 type CatBenAgg struct {
-	CategoryID     string   `json:"categoryID"`
-	Value          float32  `json:"value"`
-	BeneficiaryIDs []string `json:"beneficiaryIDs"`
-	Warnings       []string `json:"warnings"`
+	CategoryID     string             `json:"categoryID"`
+	Value          float32            `json:"value"`
+	Count          int                `json:"count"`
+	Median         float32            `json:"median"`
+	StdDev         float32            `json:"stdDev"`
+	Min            float32            `json:"min"`
+	Max            float32            `json:"max"`
+	IQR            float32            `json:"iqr"`
+	Histogram      []HistogramBin     `json:"histogram"`
+	Significance   *DeltaSignificance `json:"significance"`
+	Change         *ChangeCounts      `json:"change"`
+	BeneficiaryIDs []string           `json:"beneficiaryIDs"`
+	Warnings       []string           `json:"warnings"`
 }
 
 // QBenAgg is a BenAgg associated with a question
 type QBenAgg struct {
-	QuestionID     string   `json:"questionID"`
-	Value          float32  `json:"value"`
-	BeneficiaryIDs []string `json:"beneficiaryIDs"`
-	Warnings       []string `json:"warnings"`
+	QuestionID     string             `json:"questionID"`
+	Value          float32            `json:"value"`
+	Count          int                `json:"count"`
+	Median         float32            `json:"median"`
+	StdDev         float32            `json:"stdDev"`
+	Min            float32            `json:"min"`
+	Max            float32            `json:"max"`
+	IQR            float32            `json:"iqr"`
+	Histogram      []HistogramBin     `json:"histogram"`
+	Significance   *DeltaSignificance `json:"significance"`
+	Change         *ChangeCounts      `json:"change"`
+	BeneficiaryIDs []string           `json:"beneficiaryIDs"`
+	Warnings       []string           `json:"warnings"`
 }
 
 type Excluded struct {
@@ -34,10 +89,162 @@ type JOCQAggs struct {
 	Delta []QBenAgg `json:"delta"`
 }
 
-type JOCServiceReport struct {
+// BaselineStrategy determines which of a beneficiary's meetings is compared with their last meeting in a JOC report
+type BaselineStrategy string
+
+const (
+	// FIRSTMEETING uses the beneficiary's first ever meeting
+	FIRSTMEETING BaselineStrategy = "first"
+	// FIRSTINRANGE uses the beneficiary's first meeting within the report's date range
+	FIRSTINRANGE BaselineStrategy = "firstInRange"
+	// MARKEDBASELINE uses the meeting marked as the beneficiary's baseline
+	MARKEDBASELINE BaselineStrategy = "marked"
+	// CLOSESTTODATE uses the beneficiary's meeting conducted closest to a provided date
+	CLOSESTTODATE BaselineStrategy = "closestToDate"
+)
+
+// JOCFilters details the filters used to select the beneficiaries and meetings included in a JOC report
+type JOCFilters struct {
+	IncludeTags   []string         `json:"includeTags"`
+	ExcludeTags   []string         `json:"excludeTags"`
+	CompletedOnly bool             `json:"completedOnly"`
+	Baseline      BaselineStrategy `json:"baseline"`
+	BaselineDate  *time.Time       `json:"baselineDate"`
+}
+
+// JOCBreakdownGroup holds the aggregates of the beneficiaries sharing a value for the breakdown's profile field
+type JOCBreakdownGroup struct {
+	Value              string     `json:"value"`
 	BeneficiaryIDs     []string   `json:"beneficiaryIDs"`
 	QuestionAggregates JOCQAggs   `json:"questionAggregates"`
 	CategoryAggregates JOCCatAggs `json:"categoryAggregates"`
 	Excluded           Excluded   `json:"excluded"`
-	Warnings           []string   `json:"warnings"`
+}
+
+// JOCBreakdown splits a JOC report's aggregates by the beneficiaries' values for a profile field
+type JOCBreakdown struct {
+	FieldID string              `json:"fieldID"`
+	Groups  []JOCBreakdownGroup `json:"groups"`
+}
+
+type JOCServiceReport struct {
+	BeneficiaryIDs     []string      `json:"beneficiaryIDs"`
+	QuestionAggregates JOCQAggs      `json:"questionAggregates"`
+	CategoryAggregates JOCCatAggs    `json:"categoryAggregates"`
+	Excluded           Excluded      `json:"excluded"`
+	Filters            JOCFilters    `json:"filters"`
+	Breakdown          *JOCBreakdown `json:"breakdown"`
+	Warnings           []string      `json:"warnings"`
+}
+
+// BenQuestionValue is a beneficiary's numeric answer to a question in a single meeting
+type BenQuestionValue struct {
+	QuestionID string  `json:"questionID"`
+	Value      float32 `json:"value"`
+	// FromFirst is the change since the first meeting in which the question was answered, nil if this is that meeting
+	FromFirst *float32 `json:"fromFirst"`
+	// FromPrevious is the change since the previous meeting in which the question was answered, nil if there is no such meeting
+	FromPrevious *float32 `json:"fromPrevious"`
+}
+
+// BenCategoryValue is a beneficiary's category aggregate in a single meeting
+type BenCategoryValue struct {
+	CategoryID   string   `json:"categoryID"`
+	Value        float32  `json:"value"`
+	FromFirst    *float32 `json:"fromFirst"`
+	FromPrevious *float32 `json:"fromPrevious"`
+}
+
+// BeneficiaryReportMeeting is a single point in a beneficiary's journey
+type BeneficiaryReportMeeting struct {
+	Meeting    Meeting            `json:"meeting"`
+	Questions  []BenQuestionValue `json:"questions"`
+	Categories []BenCategoryValue `json:"categories"`
+}
+
+// BeneficiaryReport details how an individual beneficiary's answers to an outcome set have changed over time
+type BeneficiaryReport struct {
+	BeneficiaryID string                     `json:"beneficiaryID"`
+	QuestionSetID string                     `json:"questionSetID"`
+	Meetings      []BeneficiaryReportMeeting `json:"meetings"`
+	Warnings      []string                   `json:"warnings"`
+}
+
+// TrendPeriod is the calendar period used to group meetings in a trend report
+type TrendPeriod string
+
+const (
+	WEEK          TrendPeriod = "week"
+	MONTH         TrendPeriod = "month"
+	QUARTER       TrendPeriod = "quarter"
+	FINANCIALYEAR TrendPeriod = "financialYear"
+)
+
+// TrendBucket aggregates the meetings conducted within a single calendar period.
+// Each beneficiary is represented by their latest meeting within the period.
+type TrendBucket struct {
+	// Start is inclusive, End is exclusive
+	Start              time.Time   `json:"start"`
+	End                time.Time   `json:"end"`
+	Label              string      `json:"label"`
+	MeetingCount       int         `json:"meetingCount"`
+	BeneficiaryCount   int         `json:"beneficiaryCount"`
+	QuestionAggregates []QBenAgg   `json:"questionAggregates"`
+	CategoryAggregates []CatBenAgg `json:"categoryAggregates"`
+}
+
+// TrendReport details how an outcome set's aggregates move over consecutive calendar periods
+type TrendReport struct {
+	QuestionSetID string      `json:"questionSetID"`
+	Period        TrendPeriod `json:"period"`
+	// FinancialYearStart is the month, 1 to 12, in which financial years start
+	FinancialYearStart int           `json:"financialYearStart"`
+	TimeZone           string        `json:"timeZone"`
+	Buckets            []TrendBucket `json:"buckets"`
+	Warnings           []string      `json:"warnings"`
+}
+
+// VisitStep aggregates the beneficiaries' nth meetings, where Visit is n
+type VisitStep struct {
+	Visit            int `json:"visit"`
+	BeneficiaryCount int `json:"beneficiaryCount"`
+	// RetainedPercent is the percentage, between 0 and 100, of the report's beneficiaries who had at least Visit meetings
+	RetainedPercent    float32     `json:"retainedPercent"`
+	QuestionAggregates []QBenAgg   `json:"questionAggregates"`
+	CategoryAggregates []CatBenAgg `json:"categoryAggregates"`
+}
+
+// VisitReport aggregates beneficiaries' answers by the position of the meeting within their journey,
+// regardless of when the meetings were conducted
+type VisitReport struct {
+	QuestionSetID  string      `json:"questionSetID"`
+	BeneficiaryIDs []string    `json:"beneficiaryIDs"`
+	Steps          []VisitStep `json:"steps"`
+	Warnings       []string    `json:"warnings"`
+}
+
+// AttritionGroup holds the baseline aggregates of a group of beneficiaries
+type AttritionGroup struct {
+	BeneficiaryIDs     []string    `json:"beneficiaryIDs"`
+	QuestionAggregates []QBenAgg   `json:"questionAggregates"`
+	CategoryAggregates []CatBenAgg `json:"categoryAggregates"`
+}
+
+// AttritionReport details how many of the beneficiaries who started an outcome set within a date range remained engaged
+type AttritionReport struct {
+	QuestionSetID string `json:"questionSetID"`
+	// Started counts the beneficiaries whose first meeting was within the date range
+	Started int `json:"started"`
+	// FollowedUp counts the beneficiaries who had more than one meeting
+	FollowedUp      int     `json:"followedUp"`
+	FollowUpPercent float32 `json:"followUpPercent"`
+	// MedianDaysBetweenMeetings is nil if no beneficiary had a follow up meeting
+	MedianDaysBetweenMeetings *float32 `json:"medianDaysBetweenMeetings"`
+	// InactiveDays is how long a beneficiary must go without a meeting to be considered inactive
+	InactiveDays    int     `json:"inactiveDays"`
+	Inactive        int     `json:"inactive"`
+	InactivePercent float32 `json:"inactivePercent"`
+	// DroppedOut and Retained compare the baseline meetings of inactive and active beneficiaries
+	DroppedOut AttritionGroup `json:"droppedOut"`
+	Retained   AttritionGroup `json:"retained"`
 }
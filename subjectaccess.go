@@ -0,0 +1,71 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// SubjectAccessAnswer is an answer with its question resolved, as provided in a subject access export.
+// Choice answers are given as the labels of the selected choices.
+type SubjectAccessAnswer struct {
+	QuestionID  string      `json:"questionID"`
+	Question    string      `json:"question"`
+	Description string      `json:"description,omitempty"`
+	Answer      interface{} `json:"answer"`
+}
+
+// SubjectAccessMeeting is a meeting with its outcome set resolved, as provided in a subject access export
+type SubjectAccessMeeting struct {
+	ID           string                `json:"id"`
+	OutcomeSetID string                `json:"outcomeSetID"`
+	OutcomeSet   string                `json:"outcomeSet"`
+	Conducted    time.Time             `json:"conducted"`
+	Created      time.Time             `json:"created"`
+	Modified     time.Time             `json:"modified"`
+	Status       MeetingStatus         `json:"status"`
+	Deleted      bool                  `json:"deleted"`
+	Answers      []SubjectAccessAnswer `json:"answers"`
+}
+
+// SubjectAccessProfileValue is a beneficiary's value for a profile field, as provided in a subject access export
+type SubjectAccessProfileValue struct {
+	FieldID string      `json:"fieldID"`
+	Field   string      `json:"field"`
+	Value   interface{} `json:"value"`
+}
+
+// SubjectAccessExport gathers all of the data held about a beneficiary
+type SubjectAccessExport struct {
+	BeneficiaryID  string                      `json:"beneficiaryID"`
+	OrganisationID string                      `json:"organisationID"`
+	Generated      time.Time                   `json:"generated"`
+	Tags           []string                    `json:"tags"`
+	Profile        []SubjectAccessProfileValue `json:"profile"`
+	Meetings       []SubjectAccessMeeting      `json:"meetings"`
+	Changes        []BeneficiaryChange         `json:"changes"`
+}
+
+// Erasure is a tombstone recording that a beneficiary's data was erased.
+// The beneficiary ID is not retained, only a keyed hash which can be used to check whether a given beneficiary was erased.
+type Erasure struct {
+	ID              string     `json:"id" bson:"_id"`
+	OrganisationID  string     `json:"organisationID" bson:"organisationID"`
+	BeneficiaryHash string     `json:"beneficiaryHash" bson:"beneficiaryHMAC"`
+	MeetingsErased  int        `json:"meetingsErased" bson:"meetingsErased"`
+	ChangesErased   int        `json:"changesErased" bson:"changesErased"`
+	User            string     `json:"user"`
+	Created         time.Time  `json:"created"`
+	Completed       *time.Time `json:"completed"`
+}
+
+// HashBeneficiaryID returns the hash used to identify an erased beneficiary.
+// Beneficiary IDs are often short and guessable, such as initials or case numbers, so a plain hash could be reversed
+// by hashing candidate IDs. An HMAC keyed with a server secret is used instead, so the erased ID cannot be recovered
+// without the key.
+func HashBeneficiaryID(key []byte, organisationID, beneficiaryID string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(organisationID + ":" + beneficiaryID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,308 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ValidationError describes why a field of a request is invalid
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors is returned when a request fails validation, it lists every invalid field
+type ValidationErrors []ValidationError
+
+// validationErrorPrefix starts the message of every ValidationErrors
+const validationErrorPrefix = "Validation failed: "
+
+// Error lists the invalid fields as a JSON array of field and message pairs after validationErrorPrefix.
+// GraphQL clients only receive the error's message, encoding the fields allows apps to show each problem against its field.
+func (v ValidationErrors) Error() string {
+	fields, err := json.Marshal([]ValidationError(v))
+	if err != nil {
+		return validationErrorPrefix + err.Error()
+	}
+	return validationErrorPrefix + string(fields)
+}
+
+// expectedAnswerTypes maps question types to the answer type which should be used to answer them
+var expectedAnswerTypes = map[QuestionType]AnswerType{
+	LIKERT:       INT,
+	FREETEXT:     STRING,
+	SINGLECHOICE: CHOICE,
+	MULTICHOICE:  CHOICES,
+	NUMERIC:      FLOAT,
+}
+
+// ValidateAnswer checks that the answer is for an active question within the outcome set and that the
+// provided value is appropriate for the question. A ValidationErrors is returned if the answer is invalid.
+func (os *OutcomeSet) ValidateAnswer(a Answer) error {
+	q := os.GetQuestion(a.QuestionID)
+	if q == nil {
+		return ValidationErrors{{Field: "questionID", Message: "Question does not belong to the meeting's outcome set"}}
+	}
+	if q.Deleted {
+		return ValidationErrors{{Field: "questionID", Message: "Question has been archived"}}
+	}
+	if expected, ok := expectedAnswerTypes[q.Type]; !ok || expected != a.Type {
+		return ValidationErrors{{Field: "type", Message: fmt.Sprintf("A %s question cannot be answered with a %s answer", q.Type, a.Type)}}
+	}
+	if msg := q.checkAnswerValue(a); msg != "" {
+		return ValidationErrors{{Field: "answer", Message: msg}}
+	}
+	return nil
+}
+
+// checkAnswerValue returns a message describing why the answer's value is not valid for the question, or an empty string
+func (q Question) checkAnswerValue(a Answer) string {
+	switch q.Type {
+	case LIKERT:
+		value, err := a.ToFloat64()
+		if err != nil || value != math.Floor(value) {
+			return "Value must be an integer"
+		}
+		if min, ok := q.GetFloatOption("minValue"); ok && value < min {
+			return fmt.Sprintf("Value must be greater than or equal to %v", min)
+		}
+		if max, ok := q.GetFloatOption("maxValue"); ok && value > max {
+			return fmt.Sprintf("Value must be less than or equal to %v", max)
+		}
+	case NUMERIC:
+		value, err := a.ToFloat64()
+		if err != nil {
+			return "Value must be a number"
+		}
+		if err := q.CheckNumericBounds(value); err != nil {
+			return err.Error()
+		}
+	case FREETEXT:
+		if _, ok := a.Answer.(string); !ok {
+			return "Value must be a string"
+		}
+	case SINGLECHOICE, MULTICHOICE:
+		choiceIDs, err := a.ChoiceIDs()
+		if err != nil {
+			return err.Error()
+		}
+		if q.Type == SINGLECHOICE && len(choiceIDs) != 1 {
+			return "Exactly one choice must be selected"
+		}
+		seen := map[string]bool{}
+		for _, id := range choiceIDs {
+			c := q.GetChoice(id)
+			if c == nil {
+				return fmt.Sprintf("Choice %s does not belong to the question", id)
+			}
+			if c.Deleted {
+				return fmt.Sprintf("Choice %s has been removed from the question", id)
+			}
+			if seen[id] {
+				return fmt.Sprintf("Choice %s was selected more than once", id)
+			}
+			seen[id] = true
+		}
+	}
+	return ""
+}
+
+// ValidateAnswers validates a set of answers which are to be stored together, such as all the answers of a meeting.
+// Each answer is checked using ValidateAnswer and every question may only be answered once. Field names of the returned
+// ValidationErrors are prefixed with the index of the offending answer, for example answers[2].questionID.
+func (os *OutcomeSet) ValidateAnswers(answers []Answer) error {
+	out := ValidationErrors{}
+	seen := map[string]bool{}
+	for idx, a := range answers {
+		prefix := fmt.Sprintf("answers[%d].", idx)
+		if seen[a.QuestionID] {
+			out = append(out, ValidationError{Field: prefix + "questionID", Message: "Question has already been answered"})
+			continue
+		}
+		seen[a.QuestionID] = true
+		if err := os.ValidateAnswer(a); err != nil {
+			verrs, ok := err.(ValidationErrors)
+			if !ok {
+				return err
+			}
+			for _, e := range verrs {
+				out = append(out, ValidationError{Field: prefix + e.Field, Message: e.Message})
+			}
+		}
+	}
+	if len(out) > 0 {
+		return out
+	}
+	return nil
+}
+
+// ValidateComplete checks that every active question within the outcome set has been answered in the meeting.
+// A ValidationErrors is returned listing each unanswered question.
+func (os *OutcomeSet) ValidateComplete(m Meeting) error {
+	out := ValidationErrors{}
+	for _, q := range os.ActiveQuestions() {
+		if m.GetAnswer(q.ID) == nil {
+			out = append(out, ValidationError{Field: "answers", Message: fmt.Sprintf("Question %s has not been answered", q.ID)})
+		}
+	}
+	if len(out) > 0 {
+		return out
+	}
+	return nil
+}
+
+// CleanTags trims whitespace from the tags and removes duplicates, preserving order.
+// A ValidationErrors is returned if no tags are provided or a tag is blank.
+func CleanTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, ValidationErrors{{Field: "tags", Message: "At least one tag must be provided"}}
+	}
+	out := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			return nil, ValidationErrors{{Field: "tags", Message: "Tags cannot be blank"}}
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+var profileFieldTypes = map[ProfileFieldType]bool{
+	ENUMFIELD:   true,
+	DATEFIELD:   true,
+	NUMBERFIELD: true,
+	TEXTFIELD:   true,
+}
+
+// Validate checks the profile field's definition. Enum fields must have at least one option and options must be unique.
+func (f ProfileField) Validate() error {
+	out := ValidationErrors{}
+	if strings.TrimSpace(f.Name) == "" {
+		out = append(out, ValidationError{Field: "name", Message: "Name cannot be blank"})
+	}
+	if !profileFieldTypes[f.Type] {
+		out = append(out, ValidationError{Field: "type", Message: fmt.Sprintf("Unknown profile field type %s", f.Type)})
+	}
+	if f.Type == ENUMFIELD {
+		seen := map[string]bool{}
+		if len(f.Options) == 0 {
+			out = append(out, ValidationError{Field: "options", Message: "Enum fields must have at least one option"})
+		}
+		for _, o := range f.Options {
+			if strings.TrimSpace(o) == "" {
+				out = append(out, ValidationError{Field: "options", Message: "Options cannot be blank"})
+				break
+			}
+			if seen[o] {
+				out = append(out, ValidationError{Field: "options", Message: fmt.Sprintf("Option %s is listed more than once", o)})
+				break
+			}
+			seen[o] = true
+		}
+	} else if len(f.Options) > 0 {
+		out = append(out, ValidationError{Field: "options", Message: "Only enum fields can have options"})
+	}
+	if len(out) > 0 {
+		return out
+	}
+	return nil
+}
+
+// Validate checks the grouping can be used to break a report down by the field
+func (g ProfileGrouping) Validate(f ProfileField) error {
+	out := ValidationErrors{}
+	if len(g.Bands) > 0 && f.Type != NUMBERFIELD && f.Type != DATEFIELD {
+		out = append(out, ValidationError{Field: "breakdownBands", Message: "Only number and date fields can be split into bands"})
+	}
+	for i := 1; i < len(g.Bands); i++ {
+		if g.Bands[i] <= g.Bands[i-1] {
+			out = append(out, ValidationError{Field: "breakdownBands", Message: "Bands must be in ascending order"})
+			break
+		}
+	}
+	if g.PrefixLength < 0 {
+		out = append(out, ValidationError{Field: "breakdownPrefixLength", Message: "Prefix length cannot be negative"})
+	} else if g.PrefixLength > 0 && f.Type != TEXTFIELD {
+		out = append(out, ValidationError{Field: "breakdownPrefixLength", Message: "Only text fields can be grouped by prefix"})
+	}
+	if len(out) > 0 {
+		return out
+	}
+	return nil
+}
+
+// ValidateValue checks that the value can be stored in the profile field.
+// Enum and text values must be strings, numbers must be float64s and dates must be time.Times.
+func (f ProfileField) ValidateValue(value interface{}) error {
+	invalid := func(msg string) error {
+		return ValidationErrors{{Field: "value", Message: msg}}
+	}
+	if f.Deleted {
+		return ValidationErrors{{Field: "fieldID", Message: "Profile field has been deleted"}}
+	}
+	switch f.Type {
+	case ENUMFIELD:
+		s, ok := value.(string)
+		if !ok {
+			return invalid("Value must be a string")
+		}
+		if !f.HasOption(s) {
+			return invalid(fmt.Sprintf("%s is not one of the field's options", s))
+		}
+	case TEXTFIELD:
+		if _, ok := value.(string); !ok {
+			return invalid("Value must be a string")
+		}
+	case NUMBERFIELD:
+		if _, ok := value.(float64); !ok {
+			return invalid("Value must be a number")
+		}
+	case DATEFIELD:
+		if _, ok := value.(time.Time); !ok {
+			return invalid("Value must be a date")
+		}
+	default:
+		return invalid(fmt.Sprintf("Unknown profile field type %s", f.Type))
+	}
+	return nil
+}
+
+// Validate checks the change threshold. Absolute thresholds cannot be negative and reliabilities must be between 0 and 1.
+func (c ChangeThreshold) Validate() error {
+	switch c.Type {
+	case ABSOLUTETHRESHOLD:
+		if c.Value < 0 {
+			return ValidationErrors{{Field: "value", Message: "Absolute thresholds cannot be negative"}}
+		}
+	case RCITHRESHOLD:
+		if c.Value <= 0 || c.Value >= 1 {
+			return ValidationErrors{{Field: "value", Message: "Reliability must be greater than 0 and less than 1"}}
+		}
+	default:
+		return ValidationErrors{{Field: "type", Message: fmt.Sprintf("Unknown threshold type %s", c.Type)}}
+	}
+	return nil
+}
+
+// ValidateTimeZone checks that the time zone is an IANA time zone name, such as Europe/London
+func ValidateTimeZone(timeZone string) error {
+	if strings.TrimSpace(timeZone) == "" {
+		return ValidationErrors{{Field: "timeZone", Message: "Time zone cannot be blank"}}
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return ValidationErrors{{Field: "timeZone", Message: fmt.Sprintf("Unknown time zone %s", timeZone)}}
+	}
+	return nil
+}
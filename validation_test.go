@@ -0,0 +1,261 @@
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	impact "github.com/impactasaurus/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func getValidationOutcomeSet() impact.OutcomeSet {
+	score := float32(1)
+	return impact.OutcomeSet{
+		Questions: []impact.Question{{
+			ID:   "likert",
+			Type: impact.LIKERT,
+			Options: map[string]interface{}{
+				"minValue": 1,
+				"maxValue": 5,
+			},
+		}, {
+			ID:      "archived",
+			Type:    impact.LIKERT,
+			Deleted: true,
+		}, {
+			ID:   "numeric",
+			Type: impact.NUMERIC,
+			Options: map[string]interface{}{
+				"minValue": 0.0,
+				"step":     0.1,
+			},
+		}, {
+			ID:   "single",
+			Type: impact.SINGLECHOICE,
+			Choices: []impact.Choice{
+				{ID: "a", Score: &score},
+				{ID: "b"},
+			},
+		}, {
+			ID:   "multi",
+			Type: impact.MULTICHOICE,
+			Choices: []impact.Choice{
+				{ID: "a"},
+				{ID: "b"},
+				{ID: "removed", Deleted: true},
+			},
+		}, {
+			ID:   "text",
+			Type: impact.FREETEXT,
+		}},
+	}
+}
+
+func assertInvalidField(t *testing.T, err error, field string) {
+	if assert.IsType(t, impact.ValidationErrors{}, err) {
+		verr := err.(impact.ValidationErrors)
+		assert.Len(t, verr, 1)
+		assert.Equal(t, field, verr[0].Field)
+	}
+}
+
+func TestValidAnswers(t *testing.T) {
+	os := getValidationOutcomeSet()
+	valid := []impact.Answer{
+		{QuestionID: "likert", Type: impact.INT, Answer: 1},
+		{QuestionID: "likert", Type: impact.INT, Answer: 5},
+		{QuestionID: "numeric", Type: impact.FLOAT, Answer: 7.3},
+		{QuestionID: "single", Type: impact.CHOICE, Answer: "b"},
+		{QuestionID: "multi", Type: impact.CHOICES, Answer: []string{"a", "b"}},
+		{QuestionID: "multi", Type: impact.CHOICES, Answer: []interface{}{}},
+		{QuestionID: "text", Type: impact.STRING, Answer: "hello"},
+	}
+	for _, a := range valid {
+		assert.NoError(t, os.ValidateAnswer(a), "%v", a)
+	}
+}
+
+func TestInvalidAnswers(t *testing.T) {
+	os := getValidationOutcomeSet()
+	cases := []struct {
+		answer impact.Answer
+		field  string
+	}{
+		{impact.Answer{QuestionID: "missing", Type: impact.INT, Answer: 1}, "questionID"},
+		{impact.Answer{QuestionID: "archived", Type: impact.INT, Answer: 1}, "questionID"},
+		{impact.Answer{QuestionID: "likert", Type: impact.STRING, Answer: "1"}, "type"},
+		{impact.Answer{QuestionID: "likert", Type: impact.INT, Answer: 0}, "answer"},
+		{impact.Answer{QuestionID: "likert", Type: impact.INT, Answer: 6}, "answer"},
+		{impact.Answer{QuestionID: "numeric", Type: impact.FLOAT, Answer: -0.1}, "answer"},
+		{impact.Answer{QuestionID: "numeric", Type: impact.FLOAT, Answer: 0.25}, "answer"},
+		{impact.Answer{QuestionID: "single", Type: impact.CHOICE, Answer: "c"}, "answer"},
+		{impact.Answer{QuestionID: "multi", Type: impact.CHOICES, Answer: []string{"a", "a"}}, "answer"},
+		{impact.Answer{QuestionID: "multi", Type: impact.CHOICES, Answer: []string{"a", "removed"}}, "answer"},
+	}
+	for _, c := range cases {
+		assertInvalidField(t, os.ValidateAnswer(c.answer), c.field)
+	}
+}
+
+func TestValidateAnswersSet(t *testing.T) {
+	os := getValidationOutcomeSet()
+	assert.Nil(t, os.ValidateAnswers([]impact.Answer{
+		{QuestionID: "likert", Type: impact.INT, Answer: 3},
+		{QuestionID: "text", Type: impact.STRING, Answer: "fine"},
+	}))
+	assert.Nil(t, os.ValidateAnswers([]impact.Answer{}))
+
+	err := os.ValidateAnswers([]impact.Answer{
+		{QuestionID: "likert", Type: impact.INT, Answer: 3},
+		{QuestionID: "likert", Type: impact.INT, Answer: 4},
+		{QuestionID: "text", Type: impact.INT, Answer: 4},
+		{QuestionID: "unknown", Type: impact.INT, Answer: 4},
+	})
+	if assert.IsType(t, impact.ValidationErrors{}, err) {
+		verr := err.(impact.ValidationErrors)
+		if assert.Len(t, verr, 3) {
+			assert.Equal(t, "answers[1].questionID", verr[0].Field)
+			assert.Equal(t, "answers[2].type", verr[1].Field)
+			assert.Equal(t, "answers[3].questionID", verr[2].Field)
+		}
+	}
+}
+
+func TestValidateComplete(t *testing.T) {
+	os := getValidationOutcomeSet()
+	answers := []impact.Answer{
+		{QuestionID: "likert", Type: impact.INT, Answer: 3},
+		{QuestionID: "numeric", Type: impact.FLOAT, Answer: 0.5},
+		{QuestionID: "single", Type: impact.CHOICE, Answer: "a"},
+		{QuestionID: "multi", Type: impact.CHOICES, Answer: []string{"a"}},
+	}
+	err := os.ValidateComplete(impact.Meeting{Answers: answers})
+	assertInvalidField(t, err, "answers")
+	assert.Contains(t, err.Error(), "text")
+
+	// archived questions do not need to be answered
+	answers = append(answers, impact.Answer{QuestionID: "text", Type: impact.STRING, Answer: "fine"})
+	assert.Nil(t, os.ValidateComplete(impact.Meeting{Answers: answers}))
+}
+
+func TestCleanTags(t *testing.T) {
+	tags, err := impact.CleanTags([]string{" youth ", "adult", "youth"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"youth", "adult"}, tags)
+
+	_, err = impact.CleanTags([]string{})
+	assertInvalidField(t, err, "tags")
+
+	_, err = impact.CleanTags([]string{"youth", "  "})
+	assertInvalidField(t, err, "tags")
+}
+
+func TestValidateProfileField(t *testing.T) {
+	assert.Nil(t, impact.ProfileField{Name: "Gender", Type: impact.ENUMFIELD, Options: []string{"Female", "Male"}}.Validate())
+	assert.Nil(t, impact.ProfileField{Name: "Date of birth", Type: impact.DATEFIELD}.Validate())
+
+	assertInvalidField(t, impact.ProfileField{Name: " ", Type: impact.TEXTFIELD}.Validate(), "name")
+	assertInvalidField(t, impact.ProfileField{Name: "Age", Type: "age"}.Validate(), "type")
+	assertInvalidField(t, impact.ProfileField{Name: "Gender", Type: impact.ENUMFIELD}.Validate(), "options")
+	assertInvalidField(t, impact.ProfileField{Name: "Gender", Type: impact.ENUMFIELD, Options: []string{"a", "a"}}.Validate(), "options")
+	assertInvalidField(t, impact.ProfileField{Name: "Postcode", Type: impact.TEXTFIELD, Options: []string{"a"}}.Validate(), "options")
+}
+
+func TestValidateProfileValue(t *testing.T) {
+	enum := impact.ProfileField{Type: impact.ENUMFIELD, Options: []string{"Female", "Male"}}
+	assert.Nil(t, enum.ValidateValue("Female"))
+	assertInvalidField(t, enum.ValidateValue("Other"), "value")
+	assertInvalidField(t, enum.ValidateValue(1.0), "value")
+
+	text := impact.ProfileField{Type: impact.TEXTFIELD}
+	assert.Nil(t, text.ValidateValue("SW1"))
+	assertInvalidField(t, text.ValidateValue(1.0), "value")
+
+	number := impact.ProfileField{Type: impact.NUMBERFIELD}
+	assert.Nil(t, number.ValidateValue(2.5))
+	assertInvalidField(t, number.ValidateValue("2.5"), "value")
+
+	date := impact.ProfileField{Type: impact.DATEFIELD}
+	assert.Nil(t, date.ValidateValue(time.Now()))
+	assertInvalidField(t, date.ValidateValue("2017-01-01"), "value")
+
+	deleted := impact.ProfileField{Type: impact.TEXTFIELD, Deleted: true}
+	assertInvalidField(t, deleted.ValidateValue("SW1"), "fieldID")
+}
+
+func TestProfileGroupLabel(t *testing.T) {
+	f := impact.ProfileField{}
+	none := impact.ProfileGrouping{}
+	label := func(value interface{}, g impact.ProfileGrouping) string {
+		l, _ := f.GroupLabel(value, g)
+		return l
+	}
+	assert.Equal(t, "", label(nil, none))
+	assert.Equal(t, "Female", label("Female", none))
+	assert.Equal(t, "2.5", label(2.5, none))
+	assert.Equal(t, "1990", label(time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC), none))
+}
+
+func TestProfileGroupLabelBands(t *testing.T) {
+	f := impact.ProfileField{}
+	g := impact.ProfileGrouping{
+		Bands: []float64{18, 25, 65},
+		AsOf:  time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	check := func(value interface{}, expectedLabel string, expectedRank int) {
+		l, rank := f.GroupLabel(value, g)
+		assert.Equal(t, expectedLabel, l)
+		assert.Equal(t, expectedRank, rank)
+	}
+	check(17.5, "Under 18", 0)
+	check(18.0, "18 to under 25", 1)
+	check(64.9, "25 to under 65", 2)
+	check(65.0, "65 and over", 3)
+	check(time.Date(2000, 5, 1, 0, 0, 0, 0, time.UTC), "18 to under 25", 1)
+	check(time.Date(2000, 5, 2, 0, 0, 0, 0, time.UTC), "Under 18", 0)
+	check(nil, "", 0)
+}
+
+func TestProfileGroupLabelPrefix(t *testing.T) {
+	f := impact.ProfileField{}
+	g := impact.ProfileGrouping{PrefixLength: 3}
+	l, _ := f.GroupLabel("sw1a 1aa", g)
+	assert.Equal(t, "SW1", l)
+	l, _ = f.GroupLabel("E1", g)
+	assert.Equal(t, "E1", l)
+}
+
+func TestValidateProfileGrouping(t *testing.T) {
+	number := impact.ProfileField{Type: impact.NUMBERFIELD}
+	date := impact.ProfileField{Type: impact.DATEFIELD}
+	text := impact.ProfileField{Type: impact.TEXTFIELD}
+	enum := impact.ProfileField{Type: impact.ENUMFIELD, Options: []string{"a"}}
+
+	assert.Nil(t, impact.ProfileGrouping{}.Validate(enum))
+	assert.Nil(t, impact.ProfileGrouping{Bands: []float64{18, 25}}.Validate(number))
+	assert.Nil(t, impact.ProfileGrouping{Bands: []float64{18}}.Validate(date))
+	assert.Nil(t, impact.ProfileGrouping{PrefixLength: 2}.Validate(text))
+
+	assertInvalidField(t, impact.ProfileGrouping{Bands: []float64{18}}.Validate(text), "breakdownBands")
+	assertInvalidField(t, impact.ProfileGrouping{Bands: []float64{25, 18}}.Validate(number), "breakdownBands")
+	assertInvalidField(t, impact.ProfileGrouping{PrefixLength: 2}.Validate(number), "breakdownPrefixLength")
+	assertInvalidField(t, impact.ProfileGrouping{PrefixLength: -1}.Validate(text), "breakdownPrefixLength")
+}
+
+func TestValidateChangeThreshold(t *testing.T) {
+	assert.Nil(t, impact.ChangeThreshold{Type: impact.ABSOLUTETHRESHOLD, Value: 0}.Validate())
+	assert.Nil(t, impact.ChangeThreshold{Type: impact.ABSOLUTETHRESHOLD, Value: 2.5}.Validate())
+	assert.Nil(t, impact.ChangeThreshold{Type: impact.RCITHRESHOLD, Value: 0.8}.Validate())
+
+	assertInvalidField(t, impact.ChangeThreshold{Type: impact.ABSOLUTETHRESHOLD, Value: -1}.Validate(), "value")
+	assertInvalidField(t, impact.ChangeThreshold{Type: impact.RCITHRESHOLD, Value: 1}.Validate(), "value")
+	assertInvalidField(t, impact.ChangeThreshold{Type: impact.RCITHRESHOLD, Value: 0}.Validate(), "value")
+	assertInvalidField(t, impact.ChangeThreshold{Type: "percent", Value: 5}.Validate(), "type")
+}
+
+func TestValidateTimeZone(t *testing.T) {
+	assert.Nil(t, impact.ValidateTimeZone("Europe/London"))
+	assert.Nil(t, impact.ValidateTimeZone("UTC"))
+	assertInvalidField(t, impact.ValidateTimeZone(""), "timeZone")
+	assertInvalidField(t, impact.ValidateTimeZone("Europe/Atlantis"), "timeZone")
+}